@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net"
 	"net/http"
@@ -18,10 +19,18 @@ import (
 	"github.com/terkoizmy/golearn/pkg/pb/user"
 	"github.com/terkoizmy/golearn/services/user/domain"
 	"github.com/terkoizmy/golearn/services/user/handler"
+	"github.com/terkoizmy/golearn/services/user/mail"
+	"github.com/terkoizmy/golearn/services/user/middleware"
+	"github.com/terkoizmy/golearn/services/user/oauth"
+	"github.com/terkoizmy/golearn/services/user/ratelimit"
+	"github.com/terkoizmy/golearn/services/user/rbac"
 	"github.com/terkoizmy/golearn/services/user/repository"
 	"github.com/terkoizmy/golearn/services/user/service"
+	"github.com/terkoizmy/golearn/services/user/tokenstore"
 	"google.golang.org/grpc"
 
+	goredis "github.com/redis/go-redis/v9"
+
 	_ "github.com/terkoizmy/golearn/docs" // Import generated docs
 )
 
@@ -65,19 +74,44 @@ func main() {
 	}
 
 	// Auto migrate database schema
-	if err := database.AutoMigrate(db, &domain.User{}); err != nil {
+	if err := database.AutoMigrate(db, &domain.User{}, &domain.UserToken{}, &domain.RecoveryCode{}, &domain.LoginAttempt{}); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	// Initialize layers
 	userRepo := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepo, cfg.JWTSecret)
+	userTokenRepo := repository.NewUserTokenRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	tokenStore := newTokenStore(cfg.RedisURL)
+	limiter := newRateLimiter(cfg.RedisURL)
+	mailer := newMailer(cfg)
+	roles := rbac.NewRegistry(cfg.RolePermissions)
+	userService := service.NewUserService(
+		userRepo,
+		userTokenRepo,
+		recoveryCodeRepo,
+		loginAttemptRepo,
+		tokenStore,
+		mailer,
+		roles,
+		cfg.JWTSecret,
+		cfg.RequireVerifiedEmail,
+		cfg.TOTPEncryptionKey,
+		cfg.LoginMaxAttempts,
+		cfg.LoginLockDuration,
+	)
 	httpHandler := handler.NewHTTPHandler(userService)
 	grpcHandler := handler.NewGRPCHandler(userService)
 
+	oauthProviders := oauth.NewRegistry(context.Background(), cfg.OAuthProviders)
+	oauthHandler := handler.NewOAuthHandler(oauthProviders, userService)
+
+	bootstrapAdmin(context.Background(), userRepo, cfg.BootstrapAdminEmail)
+
 	// Setup HTTP server
 	router := gin.Default()
-	setupRoutes(router, httpHandler)
+	setupRoutes(router, httpHandler, oauthHandler, userService, limiter, cfg.LoginRatePerMin)
 
 	httpServer := &http.Server{
 		Addr:    ":" + cfg.HTTPPort,
@@ -129,7 +163,80 @@ func main() {
 	log.Println("✅ Servers stopped gracefully")
 }
 
-func setupRoutes(router *gin.Engine, h *handler.HTTPHandler) {
+// newTokenStore returns a Redis-backed store when REDIS_URL is configured,
+// falling back to an in-process store for local development.
+func newTokenStore(redisURL string) tokenstore.Store {
+	if redisURL == "" {
+		return tokenstore.NewMemoryStore()
+	}
+
+	opts, err := goredis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatalf("Failed to parse REDIS_URL: %v", err)
+	}
+	return tokenstore.NewRedisStore(goredis.NewClient(opts))
+}
+
+// newRateLimiter returns a Redis-backed Limiter when REDIS_URL is
+// configured, falling back to an in-process limiter for local development.
+func newRateLimiter(redisURL string) ratelimit.Limiter {
+	if redisURL == "" {
+		return ratelimit.NewMemoryLimiter()
+	}
+
+	opts, err := goredis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatalf("Failed to parse REDIS_URL: %v", err)
+	}
+	return ratelimit.NewRedisLimiter(goredis.NewClient(opts))
+}
+
+// newMailer returns an SMTP-backed Mailer when configured, falling back to
+// one that just logs the email for local development.
+func newMailer(cfg *config.Config) mail.Mailer {
+	if cfg.MailerType == "smtp" {
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		})
+	}
+	return mail.NewLogMailer()
+}
+
+// bootstrapAdmin promotes the account matching adminEmail to RoleAdmin on
+// startup, if configured, so a fresh deployment always has at least one
+// admin without requiring direct database access. A missing account is
+// tolerated since the operator may not have registered it yet.
+func bootstrapAdmin(ctx context.Context, userRepo repository.UserRepository, adminEmail string) {
+	if adminEmail == "" {
+		return
+	}
+
+	u, err := userRepo.GetByEmail(ctx, adminEmail)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			log.Printf("⚠️  BOOTSTRAP_ADMIN_EMAIL %s not registered yet; skipping admin bootstrap", adminEmail)
+			return
+		}
+		log.Printf("⚠️  failed to look up bootstrap admin %s: %v", adminEmail, err)
+		return
+	}
+
+	if u.Role == domain.RoleAdmin {
+		return
+	}
+
+	if err := userRepo.UpdateRole(ctx, u.ID, string(domain.RoleAdmin)); err != nil {
+		log.Printf("⚠️  failed to promote bootstrap admin %s: %v", adminEmail, err)
+		return
+	}
+	log.Printf("✅ promoted %s to admin", adminEmail)
+}
+
+func setupRoutes(router *gin.Engine, h *handler.HTTPHandler, oauthHandler *handler.OAuthHandler, userService service.UserService, limiter ratelimit.Limiter, loginRatePerMin int) {
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -145,10 +252,29 @@ func setupRoutes(router *gin.Engine, h *handler.HTTPHandler) {
 	api := router.Group("/api/v1")
 	{
 		// Public routes
-		api.POST("/register", h.Register)
-		api.POST("/login", h.Login)
+		ipRateLimit := middleware.RateLimitPerIP(limiter, loginRatePerMin)
+		emailRateLimit := middleware.RateLimitPerEmail(limiter, loginRatePerMin)
+		api.POST("/register", ipRateLimit, emailRateLimit, h.Register)
+		api.POST("/login", ipRateLimit, emailRateLimit, h.Login)
+		api.POST("/refresh", h.Refresh)
+		api.POST("/logout", h.Logout)
+		api.GET("/verify", h.VerifyEmail)
+		api.POST("/password-reset/request", h.RequestPasswordReset)
+		api.POST("/password-reset/confirm", h.ConfirmPasswordReset)
+		api.POST("/login/2fa", ipRateLimit, h.LoginTOTP)
 
 		// Protected routes
 		api.GET("/users/:id", h.GetUser)
+		api.POST("/2fa/enroll", h.EnrollTOTP)
+		api.POST("/2fa/confirm", h.ConfirmTOTP)
+		api.POST("/2fa/disable", h.DisableTOTP)
+
+		// Admin-only user management
+		api.GET("/users", middleware.RequireRole(userService, "admin"), h.ListUsers)
+		api.PATCH("/users/:id/role", middleware.RequireRole(userService, "admin"), h.UpdateUserRole)
+
+		// SSO login
+		api.GET("/oauth/:provider/login", oauthHandler.Login)
+		api.GET("/oauth/:provider/callback", oauthHandler.Callback)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/repository"
+)
+
+// fakeUserRepository is an in-memory stand-in for repository.UserRepository
+// that backs the single lookup/update pair bootstrapAdmin performs.
+type fakeUserRepository struct {
+	repository.UserRepository
+	user        *domain.User
+	updatedRole string
+}
+
+func (f *fakeUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if f.user == nil || f.user.Email != email {
+		return nil, repository.ErrUserNotFound
+	}
+	return f.user, nil
+}
+
+func (f *fakeUserRepository) UpdateRole(ctx context.Context, userID, role string) error {
+	f.updatedRole = role
+	return nil
+}
+
+func TestBootstrapAdminPromotesMatchingAccount(t *testing.T) {
+	repo := &fakeUserRepository{user: &domain.User{ID: "user-1", Email: "admin@example.com", Role: domain.RoleUser}}
+
+	bootstrapAdmin(context.Background(), repo, "admin@example.com")
+
+	if repo.updatedRole != string(domain.RoleAdmin) {
+		t.Fatalf("bootstrapAdmin() updatedRole = %q, want %q", repo.updatedRole, domain.RoleAdmin)
+	}
+}
+
+func TestBootstrapAdminSkipsWhenNoEmailConfigured(t *testing.T) {
+	repo := &fakeUserRepository{user: &domain.User{ID: "user-1", Email: "admin@example.com", Role: domain.RoleUser}}
+
+	bootstrapAdmin(context.Background(), repo, "")
+
+	if repo.updatedRole != "" {
+		t.Fatalf("bootstrapAdmin() promoted an account despite no admin email being configured")
+	}
+}
+
+func TestBootstrapAdminSkipsAlreadyAdmin(t *testing.T) {
+	repo := &fakeUserRepository{user: &domain.User{ID: "user-1", Email: "admin@example.com", Role: domain.RoleAdmin}}
+
+	bootstrapAdmin(context.Background(), repo, "admin@example.com")
+
+	if repo.updatedRole != "" {
+		t.Fatalf("bootstrapAdmin() called UpdateRole on an account that's already admin")
+	}
+}
+
+func TestBootstrapAdminSkipsUnknownEmail(t *testing.T) {
+	repo := &fakeUserRepository{}
+
+	bootstrapAdmin(context.Background(), repo, "missing@example.com")
+
+	if repo.updatedRole != "" {
+		t.Fatalf("bootstrapAdmin() called UpdateRole despite no matching account")
+	}
+}
@@ -3,16 +3,66 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DatabaseURL string
-	JWTSecret   string
-	HTTPPort    string
-	GRPCPort    string
-	Environment string
+	DatabaseURL    string
+	JWTSecret      string
+	HTTPPort       string
+	GRPCPort       string
+	Environment    string
+	OAuthProviders map[string]OAuthProviderConfig
+	// RedisURL enables the Redis-backed refresh token store when set; an
+	// in-process store is used otherwise.
+	RedisURL string
+	// MailerType selects the Mailer implementation: "smtp" or "log" (the
+	// default, which just logs the email instead of sending it).
+	MailerType string
+	SMTP       SMTPConfig
+	// RequireVerifiedEmail rejects login for accounts that haven't
+	// completed the verify-email flow yet.
+	RequireVerifiedEmail bool
+	// TOTPEncryptionKey encrypts domain.User.TOTPSecret at rest. Must be
+	// 16, 24, or 32 bytes (AES-128/192/256); change it in production.
+	TOTPEncryptionKey string
+	// RolePermissions overlays the built-in user/admin roles with
+	// additional role -> permissions entries, keyed by role name.
+	RolePermissions map[string][]string
+	// BootstrapAdminEmail, if set, promotes the matching account to
+	// RoleAdmin on startup so a fresh deployment has at least one admin.
+	BootstrapAdminEmail string
+	// LoginMaxAttempts is how many consecutive failed logins a user gets
+	// before their account is locked for LoginLockDuration.
+	LoginMaxAttempts  int
+	LoginLockDuration time.Duration
+	// LoginRatePerMin caps how many /login or /register requests a single
+	// client IP may make per minute.
+	LoginRatePerMin int
+}
+
+// SMTPConfig holds the connection details used by the SMTP mailer.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// OAuthProviderConfig holds the client credentials and endpoint details
+// needed to run the authorization-code flow against a single SSO provider.
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// IssuerURL is only used by the generic OIDC provider for discovery.
+	IssuerURL string
 }
 
 func Load() (*Config, error) {
@@ -20,11 +70,28 @@ func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		DatabaseURL: getEnv("DATABASE_URL", ""),
-		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-		HTTPPort:    getEnv("HTTP_PORT", "8080"),
-		GRPCPort:    getEnv("GRPC_PORT", "50051"),
-		Environment: getEnv("ENVIRONMENT", "development"),
+		DatabaseURL:    getEnv("DATABASE_URL", ""),
+		JWTSecret:      getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+		HTTPPort:       getEnv("HTTP_PORT", "8080"),
+		GRPCPort:       getEnv("GRPC_PORT", "50051"),
+		Environment:    getEnv("ENVIRONMENT", "development"),
+		OAuthProviders: loadOAuthProviders(),
+		RedisURL:       getEnv("REDIS_URL", ""),
+		MailerType:     getEnv("MAILER_TYPE", "log"),
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		RequireVerifiedEmail: getEnvBool("REQUIRE_VERIFIED_EMAIL", false),
+		TOTPEncryptionKey:    getEnv("TOTP_ENCRYPTION_KEY", "dev-totp-encryption-key-32bytes!"),
+		RolePermissions:      loadRolePermissions(),
+		BootstrapAdminEmail:  getEnv("BOOTSTRAP_ADMIN_EMAIL", ""),
+		LoginMaxAttempts:     getEnvInt("LOGIN_MAX_ATTEMPTS", 5),
+		LoginLockDuration:    getEnvDuration("LOGIN_LOCK_DURATION", 15*time.Minute),
+		LoginRatePerMin:      getEnvInt("LOGIN_RATE_PER_MIN", 10),
 	}
 
 	// Validate required fields
@@ -35,9 +102,102 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// loadOAuthProviders builds the per-provider OAuth2/OIDC configuration from
+// environment variables. A provider is only registered when its client ID is
+// set, so deployments that don't use SSO pay no configuration cost.
+func loadOAuthProviders() map[string]OAuthProviderConfig {
+	providers := map[string]OAuthProviderConfig{}
+
+	if clientID := getEnv("GOOGLE_CLIENT_ID", ""); clientID != "" {
+		providers["google"] = OAuthProviderConfig{
+			ClientID:     clientID,
+			ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", ""),
+			Scopes:       splitScopes(getEnv("GOOGLE_SCOPES", "openid,email,profile")),
+		}
+	}
+
+	if clientID := getEnv("GITHUB_CLIENT_ID", ""); clientID != "" {
+		providers["github"] = OAuthProviderConfig{
+			ClientID:     clientID,
+			ClientSecret: getEnv("GITHUB_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("GITHUB_REDIRECT_URL", ""),
+			Scopes:       splitScopes(getEnv("GITHUB_SCOPES", "read:user,user:email")),
+		}
+	}
+
+	if clientID := getEnv("OIDC_CLIENT_ID", ""); clientID != "" {
+		providers["oidc"] = OAuthProviderConfig{
+			ClientID:     clientID,
+			ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", ""),
+			Scopes:       splitScopes(getEnv("OIDC_SCOPES", "openid,email,profile")),
+			IssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+		}
+	}
+
+	return providers
+}
+
+// loadRolePermissions parses ROLE_PERMISSIONS, a ";"-separated list of
+// "role=perm1|perm2" entries, into a role -> permissions map. It's used to
+// add custom roles on top of rbac's built-in user/admin roles.
+func loadRolePermissions() map[string][]string {
+	raw := getEnv("ROLE_PERMISSIONS", "")
+	if raw == "" {
+		return nil
+	}
+
+	roles := map[string][]string{}
+	for _, entry := range strings.Split(raw, ";") {
+		role, rawPerms, ok := strings.Cut(entry, "=")
+		if !ok || role == "" {
+			continue
+		}
+		perms := strings.Split(rawPerms, "|")
+		for i, p := range perms {
+			perms[i] = strings.TrimSpace(p)
+		}
+		roles[role] = perms
+	}
+	return roles
+}
+
+func splitScopes(raw string) []string {
+	scopes := strings.Split(raw, ",")
+	for i, s := range scopes {
+		scopes[i] = strings.TrimSpace(s)
+	}
+	return scopes
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, strconv.FormatBool(defaultValue)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, defaultValue.String()))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
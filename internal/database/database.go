@@ -0,0 +1,16 @@
+package database
+
+import (
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// NewGormDB opens a GORM connection backed by Postgres.
+func NewGormDB(dsn string) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+}
+
+// AutoMigrate runs GORM's schema auto-migration for the given models.
+func AutoMigrate(db *gorm.DB, models ...interface{}) error {
+	return db.AutoMigrate(models...)
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// AccessTokenTTL is how long an access JWT issued by GenerateJWT is valid.
+// Logout denylists the token's jti so it stops working earlier; a role or
+// 2FA change only shows up in tokens issued after it.
+const AccessTokenTTL = 15 * time.Minute
+
+// ChallengeTokenTTL is how long a 2FA challenge JWT issued by
+// GenerateChallengeJWT remains valid before the login attempt must be
+// restarted from scratch.
+const ChallengeTokenTTL = 5 * time.Minute
+
+// PurposeTOTPChallenge marks a JWT as a short-lived second-factor
+// challenge rather than a full access token. ValidateJWT accepts such a
+// token like any other; service.UserService.ValidateToken rejects it by
+// this claim.
+const PurposeTOTPChallenge = "2fa"
+
+// Claims represents the custom JWT claims issued for an authenticated user.
+type Claims struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	// Role and Permissions let the API gateway and RequireRole/
+	// RequirePermission middleware authorize a request from the token
+	// alone, without a round trip to the user service.
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// Purpose is empty for a normal access token, and PurposeTOTPChallenge
+	// for the short-lived token standing in for one during a 2FA login.
+	Purpose string `json:"purpose,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// GenerateJWT creates a signed access JWT for the given user, valid for
+// AccessTokenTTL, and returns its jti alongside it so callers can track or
+// revoke that specific token later (see tokenstore.Store.DenylistJTI).
+func GenerateJWT(userID, email, role string, permissions []string, secret string) (token string, jti string, err error) {
+	jti = uuid.New().String()
+	claims := Claims{
+		UserID:      userID,
+		Email:       email,
+		Role:        role,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		return "", "", err
+	}
+	return signed, jti, nil
+}
+
+// GenerateChallengeJWT creates a signed, short-lived JWT standing in for a
+// completed login while a TOTP-enabled user finishes their second factor.
+// It carries no email and is only ever accepted by LoginWithTOTP.
+func GenerateChallengeJWT(userID, secret string) (string, error) {
+	claims := Claims{
+		UserID:  userID,
+		Purpose: PurposeTOTPChallenge,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ChallengeTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// ValidateJWT parses and validates a JWT, returning its claims if valid.
+func ValidateJWT(tokenString, secret string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
@@ -0,0 +1,17 @@
+package util
+
+import "golang.org/x/crypto/bcrypt"
+
+// HashPassword hashes a plaintext password for storage.
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// CheckPassword reports whether password matches the given bcrypt hash.
+func CheckPassword(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// GenerateRefreshToken returns a random, opaque refresh token. Unlike the
+// access JWT it carries no claims of its own; its only job is to be an
+// unguessable lookup key into a tokenstore.Store.
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateTOTPCodeWindow(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	counter := uint64(now.Unix()) / totpStepSeconds
+
+	currentCode, err := hotp(secret, counter)
+	if err != nil {
+		t.Fatalf("hotp() error = %v", err)
+	}
+	if !ValidateTOTPCode(secret, currentCode, now) {
+		t.Fatalf("ValidateTOTPCode() = false for the current step, want true")
+	}
+
+	prevCode, err := hotp(secret, counter-1)
+	if err != nil {
+		t.Fatalf("hotp() error = %v", err)
+	}
+	if !ValidateTOTPCode(secret, prevCode, now) {
+		t.Fatalf("ValidateTOTPCode() = false for the previous step, want true (±1 window)")
+	}
+
+	nextCode, err := hotp(secret, counter+1)
+	if err != nil {
+		t.Fatalf("hotp() error = %v", err)
+	}
+	if !ValidateTOTPCode(secret, nextCode, now) {
+		t.Fatalf("ValidateTOTPCode() = false for the next step, want true (±1 window)")
+	}
+
+	// Two steps outside the window must be rejected.
+	outOfWindowCode, err := hotp(secret, counter+2)
+	if err != nil {
+		t.Fatalf("hotp() error = %v", err)
+	}
+	if ValidateTOTPCode(secret, outOfWindowCode, now) {
+		t.Fatalf("ValidateTOTPCode() = true for a step 2 outside the window, want false")
+	}
+
+	if ValidateTOTPCode(secret, "000000", now) {
+		t.Fatalf("ValidateTOTPCode() = true for a garbage code, want false")
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// LoginAttempt tracks consecutive failed login attempts for a single user,
+// so the service can lock the account out after too many in a row.
+// LoginAttemptRepository.Reset sets it back to zero rather than deleting the row, to
+// avoid a create/delete cycle on every login.
+type LoginAttempt struct {
+	UserID       string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	FailedCount  int       `gorm:"not null;default:0" json:"failed_count"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
@@ -7,14 +7,46 @@ import (
 	"gorm.io/gorm"
 )
 
+// Role is a user's RBAC role. The built-in roles are RoleUser and
+// RoleAdmin; deployments can register additional roles (and the
+// permissions that come with them) via the rbac package without a code
+// change.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
 // User represents a user entity in the system
 type User struct {
-	ID        string    `gorm:"type:uuid;primaryKey" json:"id"`
-	Email     string    `gorm:"uniqueIndex;not null" json:"email"`
-	Name      string    `gorm:"not null" json:"name"`
-	Password  string    `gorm:"not null" json:"-"` // Never expose password in JSON
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID       string `gorm:"type:uuid;primaryKey" json:"id"`
+	Email    string `gorm:"uniqueIndex;not null" json:"email"`
+	Name     string `gorm:"not null" json:"name"`
+	Password string `gorm:"" json:"-"` // Empty for SSO-only accounts; never expose in JSON
+	// Role drives the permissions embedded in this user's JWTs; see the
+	// rbac package.
+	Role Role `gorm:"not null;default:user" json:"role"`
+	// OAuthProvider/OAuthSubject identify an SSO-linked account (e.g.
+	// provider "google", subject the provider's stable user id). Both are
+	// empty for password accounts.
+	OAuthProvider string `gorm:"column:oauth_provider;index:idx_oauth_identity,unique" json:"-"`
+	OAuthSubject  string `gorm:"column:oauth_subject;index:idx_oauth_identity,unique" json:"-"`
+	// EmailVerifiedAt is nil until the user completes the verify-email flow.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// TOTPSecret is the user's TOTP seed, encrypted at rest with
+	// internal/config's TOTPEncryptionKey; it is empty until TOTP is
+	// enrolled. TOTPEnabled only flips on once enrollment is confirmed
+	// with a valid code, so a half-finished enrollment can't lock anyone
+	// out.
+	TOTPSecret  string    `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabled bool      `gorm:"column:totp_enabled;not null;default:false" json:"totp_enabled"`
+	// LockedUntil is set once too many consecutive login failures are
+	// recorded (see repository.LoginAttemptRepository); login is rejected
+	// with ErrAccountLocked until this time passes.
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -30,11 +62,13 @@ func (User) TableName() string {
 	return "users"
 }
 
-// RegisterRequest represents user registration input
+// RegisterRequest represents user registration input. Password is optional
+// because SSO-created accounts (see the oauth package) have no password of
+// their own; when present it is still validated as usual.
 type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email" example:"user@example.com"`
 	Name     string `json:"name" binding:"required,min=2,max=100" example:"John Doe"`
-	Password string `json:"password" binding:"required,min=6" example:"password123"`
+	Password string `json:"password" binding:"omitempty,min=6" example:"password123"`
 }
 
 // LoginRequest represents user login input
@@ -43,10 +77,80 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required" example:"password123"`
 }
 
-// LoginResponse represents successful login output
+// LoginResponse represents successful login output. For a TOTP-enabled
+// account, Login returns only ChallengeToken (everything else is empty)
+// and the caller must complete Login2FARequest against /login/2fa to get
+// a real Token/RefreshToken/User.
 type LoginResponse struct {
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	User  *User  `json:"user"`
+	Token          string `json:"token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken   string `json:"refresh_token,omitempty" example:"8k1Qw...opaque..."`
+	User           *User  `json:"user,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// RefreshRequest represents a refresh-token exchange
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest represents a logout/token-revocation request
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// PasswordResetRequestRequest represents a request to start a password reset
+type PasswordResetRequestRequest struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// PasswordResetConfirmRequest represents the second step of a password reset
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6" example:"newpassword123"`
+}
+
+// TOTPEnrollResponse carries the seed a user needs to set up an
+// authenticator app. Secret is also embedded in OTPAuthURL so a client can
+// render either as a QR code.
+type TOTPEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OTPAuthURL string `json:"otpauth_url" example:"otpauth://totp/golearn:user@example.com?secret=JBSWY3DPEHPK3PXP&issuer=golearn"`
+}
+
+// TOTPConfirmRequest represents the first code used to confirm enrollment
+type TOTPConfirmRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// TOTPRecoveryCodesResponse carries the one-time view of freshly issued
+// recovery codes; only their bcrypt hashes are persisted.
+type TOTPRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TOTPDisableRequest represents a request to turn 2FA back off
+type TOTPDisableRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// Login2FARequest represents the second step of a 2FA login, redeeming the
+// challenge token Login returned for a real session
+type Login2FARequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// TokenClaims is the authenticated identity and authorization context
+// extracted from a validated access token.
+type TokenClaims struct {
+	UserID      string
+	Role        string
+	Permissions []string
+}
+
+// UpdateRoleRequest represents an admin's request to change a user's role
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required"`
 }
 
 // UserResponse represents user data without sensitive info
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecoveryCode is a single-use, bcrypt-hashed backup code a user can redeem
+// instead of a TOTP code if they lose access to their authenticator app.
+// Only CodeHash is ever persisted; the raw code is shown once, at
+// enrollment time, and never stored.
+type RecoveryCode struct {
+	ID        string     `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    string     `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID
+func (c *RecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenPurpose distinguishes the single-use tokens stored in user_tokens so
+// a verify-email token can't be replayed to reset a password, and vice
+// versa.
+type TokenPurpose string
+
+const (
+	TokenPurposeVerifyEmail   TokenPurpose = "verify_email"
+	TokenPurposeResetPassword TokenPurpose = "reset_password"
+)
+
+// UserToken is a single-use, hashed token backing the email-verification
+// and password-reset flows. Only TokenHash is ever persisted; the raw
+// token is handed to the user once (via the Mailer) and never stored.
+type UserToken struct {
+	ID        string       `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    string       `gorm:"not null;index" json:"user_id"`
+	TokenHash string       `gorm:"uniqueIndex;not null" json:"-"`
+	Purpose   TokenPurpose `gorm:"not null" json:"purpose"`
+	ExpiresAt time.Time    `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time   `json:"used_at,omitempty"`
+	CreatedAt time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID
+func (t *UserToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
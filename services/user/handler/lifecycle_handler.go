@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/service"
+)
+
+// VerifyEmail godoc
+// @Summary Verify an email address
+// @Description Redeems a verify_email token sent to the user's inbox
+// @Tags Authentication
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid or expired token"
+// @Router /api/v1/verify [get]
+func (h *HTTPHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "token is required"})
+		return
+	}
+
+	if err := h.service.VerifyEmail(c.Request.Context(), token); err != nil {
+		if errors.Is(err, service.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid or expired token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to verify email"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RequestPasswordReset godoc
+// @Summary Request a password reset
+// @Description Emails a reset_password token if the address is registered
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body domain.PasswordResetRequestRequest true "Email to reset"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Router /api/v1/password-reset/request [post]
+func (h *HTTPHandler) RequestPasswordReset(c *gin.Context) {
+	var req domain.PasswordResetRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Always 204: whether the email exists is not revealed to the caller.
+	if err := h.service.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to request password reset"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ConfirmPasswordReset godoc
+// @Summary Confirm a password reset
+// @Description Redeems a reset_password token and sets the new password
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body domain.PasswordResetConfirmRequest true "Token and new password"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid input, token, or expired token"
+// @Router /api/v1/password-reset/confirm [post]
+func (h *HTTPHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req domain.PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.service.ConfirmPasswordReset(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		if errors.Is(err, service.ErrInvalidToken) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid or expired token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to reset password"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
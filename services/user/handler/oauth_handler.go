@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/oauth"
+	"github.com/terkoizmy/golearn/services/user/service"
+)
+
+const oauthStateCookie = "golearn_oauth_state"
+
+// OAuthHandler exposes the SSO login/callback routes for every configured
+// oauth.Provider.
+type OAuthHandler struct {
+	providers oauth.Registry
+	service   service.UserService
+}
+
+func NewOAuthHandler(providers oauth.Registry, service service.UserService) *OAuthHandler {
+	return &OAuthHandler{providers: providers, service: service}
+}
+
+// Login godoc
+// @Summary Start an SSO login
+// @Description Redirects the browser to the provider's authorization endpoint
+// @Tags Authentication
+// @Param provider path string true "Provider key (google, github, oidc)"
+// @Success 302
+// @Failure 404 {object} domain.ErrorResponse "Unknown provider"
+// @Router /api/v1/oauth/{provider}/login [get]
+func (h *OAuthHandler) Login(c *gin.Context) {
+	provider, ok := h.providers.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Error: "Unknown OAuth provider"})
+		return
+	}
+
+	state, err := generateState()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to start OAuth login"})
+		return
+	}
+
+	// HttpOnly + short-lived so only this browser round-trip can present it.
+	c.SetCookie(oauthStateCookie, state, 300, "/", "", false, true)
+	c.Redirect(http.StatusFound, provider.AuthCodeURL(state))
+}
+
+// Callback godoc
+// @Summary Complete an SSO login
+// @Description Validates state, exchanges the code, and logs the user in
+// @Tags Authentication
+// @Param provider path string true "Provider key (google, github, oidc)"
+// @Param code query string true "Authorization code"
+// @Param state query string true "CSRF state"
+// @Success 200 {object} domain.LoginResponse "Login successful"
+// @Failure 400 {object} domain.ErrorResponse "Invalid state or code"
+// @Failure 404 {object} domain.ErrorResponse "Unknown provider"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/oauth/{provider}/callback [get]
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	provider, ok := h.providers.Get(c.Param("provider"))
+	if !ok {
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Error: "Unknown OAuth provider"})
+		return
+	}
+
+	cookieState, err := c.Cookie(oauthStateCookie)
+	if err != nil || cookieState == "" || !statesMatch(cookieState, c.Query("state")) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid or expired OAuth state"})
+		return
+	}
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", false, true)
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Missing authorization code"})
+		return
+	}
+
+	accessToken, err := provider.Exchange(c.Request.Context(), code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Failed to exchange authorization code"})
+		return
+	}
+
+	info, err := provider.FetchUserInfo(c.Request.Context(), accessToken)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to fetch user info from provider"})
+		return
+	}
+
+	response, err := h.service.LoginWithOAuth(c.Request.Context(), provider.Name(), info.Subject, info.Email, info.Name, info.EmailVerified)
+	if err != nil {
+		if errors.Is(err, service.ErrOAuthEmailNotVerified) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Error: "This email is already registered and the provider has not verified it"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to complete OAuth login"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func generateState() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func statesMatch(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/service"
+)
+
+// ListUsers godoc
+// @Summary List users
+// @Description Lists every user; requires the admin role
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.User "Users"
+// @Failure 401 {object} domain.ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} domain.ErrorResponse "Insufficient role"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/users [get]
+func (h *HTTPHandler) ListUsers(c *gin.Context) {
+	users, err := h.service.ListUsers(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to list users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// UpdateUserRole godoc
+// @Summary Update a user's role
+// @Description Assigns a new RBAC role to a user; requires the admin role
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID (UUID)"
+// @Param request body domain.UpdateRoleRequest true "New role"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid input or unknown role"
+// @Failure 401 {object} domain.ErrorResponse "Missing or invalid token"
+// @Failure 403 {object} domain.ErrorResponse "Insufficient role"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/users/{id}/role [patch]
+func (h *HTTPHandler) UpdateUserRole(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.service.UpdateUserRole(c.Request.Context(), id, req.Role); err != nil {
+		if errors.Is(err, service.ErrInvalidRole) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Unknown role"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to update role"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
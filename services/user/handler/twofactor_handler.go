@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/service"
+)
+
+// authenticatedUserID extracts and validates the bearer access token from
+// the request, writing a 401 response and returning ok=false if it's
+// missing or invalid.
+func (h *HTTPHandler) authenticatedUserID(c *gin.Context) (userID string, ok bool) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "authorization token is required"})
+		return "", false
+	}
+
+	claims, err := h.service.ValidateToken(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "invalid or expired token"})
+		return "", false
+	}
+
+	return claims.UserID, true
+}
+
+// EnrollTOTP godoc
+// @Summary Enroll in TOTP 2FA
+// @Description Generates a new TOTP secret for the authenticated user; 2FA isn't enforced until ConfirmTOTP succeeds
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.TOTPEnrollResponse "Secret and otpauth:// URL for QR rendering"
+// @Failure 401 {object} domain.ErrorResponse "Missing or invalid token"
+// @Failure 409 {object} domain.ErrorResponse "2FA already enabled"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/2fa/enroll [post]
+func (h *HTTPHandler) EnrollTOTP(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.EnrollTOTP(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrTOTPAlreadyEnabled) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Error: "Two-factor authentication is already enabled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to enroll in 2FA"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP 2FA
+// @Description Verifies the first code from the authenticator app and enables 2FA, returning one-time recovery codes
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.TOTPConfirmRequest true "First TOTP code"
+// @Success 200 {object} domain.TOTPRecoveryCodesResponse "2FA enabled; save these recovery codes"
+// @Failure 400 {object} domain.ErrorResponse "Invalid code"
+// @Failure 401 {object} domain.ErrorResponse "Missing or invalid token"
+// @Failure 409 {object} domain.ErrorResponse "2FA already enabled"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/2fa/confirm [post]
+func (h *HTTPHandler) ConfirmTOTP(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TOTPConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTOTPCode) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid code"})
+			return
+		}
+		if errors.Is(err, service.ErrTOTPAlreadyEnabled) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Error: "Two-factor authentication is already enabled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to confirm 2FA"})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.TOTPRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP godoc
+// @Summary Disable TOTP 2FA
+// @Description Verifies a current code and turns 2FA back off
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.TOTPDisableRequest true "Current TOTP code"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid code"
+// @Failure 401 {object} domain.ErrorResponse "Missing or invalid token"
+// @Failure 409 {object} domain.ErrorResponse "2FA not enabled"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/2fa/disable [post]
+func (h *HTTPHandler) DisableTOTP(c *gin.Context) {
+	userID, ok := h.authenticatedUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TOTPDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := h.service.DisableTOTP(c.Request.Context(), userID, req.Code); err != nil {
+		if errors.Is(err, service.ErrInvalidTOTPCode) {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: "Invalid code"})
+			return
+		}
+		if errors.Is(err, service.ErrTOTPNotEnabled) {
+			c.JSON(http.StatusConflict, domain.ErrorResponse{Error: "Two-factor authentication is not enabled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to disable 2FA"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LoginTOTP godoc
+// @Summary Complete a 2FA login
+// @Description Redeems the challenge token from Login for a real access/refresh pair, given a TOTP or recovery code
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body domain.Login2FARequest true "Challenge token and code"
+// @Success 200 {object} domain.LoginResponse "Login successful"
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Invalid challenge token or code"
+// @Failure 429 {object} domain.ErrorResponse "Too many requests"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/login/2fa [post]
+func (h *HTTPHandler) LoginTOTP(c *gin.Context) {
+	var req domain.Login2FARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.service.LoginWithTOTP(c.Request.Context(), req.ChallengeToken, req.Code)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidChallengeToken) || errors.Is(err, service.ErrInvalidTOTPCode) {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Invalid challenge token or code"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to complete 2FA login"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
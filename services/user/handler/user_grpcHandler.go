@@ -5,12 +5,22 @@ import (
 	"errors"
 
 	"github.com/terkoizmy/golearn/pkg/pb/user"
+	"github.com/terkoizmy/golearn/services/user/domain"
 	"github.com/terkoizmy/golearn/services/user/repository"
 	"github.com/terkoizmy/golearn/services/user/service"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// grpcErrorForRefresh maps the service-layer refresh/logout errors onto the
+// gRPC status codes the API gateway expects.
+func grpcErrorForRefresh(err error) error {
+	if errors.Is(err, service.ErrInvalidRefreshToken) {
+		return status.Error(codes.Unauthenticated, "invalid or expired refresh token")
+	}
+	return status.Error(codes.Internal, "failed to refresh token")
+}
+
 type GRPCHandler struct {
 	user.UnimplementedUserServiceServer
 	service service.UserService
@@ -49,7 +59,7 @@ func (h *GRPCHandler) ValidateToken(ctx context.Context, req *user.ValidateToken
 		}, nil
 	}
 
-	userID, err := h.service.ValidateToken(ctx, req.Token)
+	claims, err := h.service.ValidateToken(ctx, req.Token)
 	if err != nil {
 		return &user.ValidateTokenResponse{
 			Valid:   false,
@@ -58,8 +68,131 @@ func (h *GRPCHandler) ValidateToken(ctx context.Context, req *user.ValidateToken
 	}
 
 	return &user.ValidateTokenResponse{
-		Valid:   true,
-		UserId:  userID,
-		Message: "token is valid",
+		Valid:       true,
+		UserId:      claims.UserID,
+		Message:     "token is valid",
+		Role:        claims.Role,
+		Permissions: claims.Permissions,
+	}, nil
+}
+
+func (h *GRPCHandler) RefreshToken(ctx context.Context, req *user.RefreshTokenRequest) (*user.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	response, err := h.service.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, grpcErrorForRefresh(err)
+	}
+
+	return &user.RefreshTokenResponse{
+		AccessToken:  response.Token,
+		RefreshToken: response.RefreshToken,
+	}, nil
+}
+
+func (h *GRPCHandler) RevokeToken(ctx context.Context, req *user.RevokeTokenRequest) (*user.RevokeTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+
+	if err := h.service.Logout(ctx, "", req.RefreshToken); err != nil {
+		return nil, status.Error(codes.Internal, "failed to revoke token")
+	}
+
+	return &user.RevokeTokenResponse{Revoked: true}, nil
+}
+
+func (h *GRPCHandler) Login(ctx context.Context, req *user.LoginRequest) (*user.LoginResponse, error) {
+	if req.Email == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+
+	response, err := h.service.Login(ctx, &domain.LoginRequest{Email: req.Email, Password: req.Password})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) || errors.Is(err, service.ErrSSOOnlyAccount) {
+			return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+		}
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			return nil, status.Error(codes.FailedPrecondition, "email not verified")
+		}
+		if errors.Is(err, service.ErrAccountLocked) {
+			return nil, status.Error(codes.ResourceExhausted, "account is temporarily locked due to too many failed login attempts")
+		}
+		return nil, status.Error(codes.Internal, "failed to login")
+	}
+
+	if response.ChallengeToken != "" {
+		return &user.LoginResponse{ChallengeToken: response.ChallengeToken}, nil
+	}
+
+	return &user.LoginResponse{
+		AccessToken:  response.Token,
+		RefreshToken: response.RefreshToken,
+		UserId:       response.User.ID,
 	}, nil
 }
+
+func (h *GRPCHandler) LoginTOTP(ctx context.Context, req *user.Login2FARequest) (*user.LoginResponse, error) {
+	if req.ChallengeToken == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "challenge_token and code are required")
+	}
+
+	response, err := h.service.LoginWithTOTP(ctx, req.ChallengeToken, req.Code)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidChallengeToken) || errors.Is(err, service.ErrInvalidTOTPCode) {
+			return nil, status.Error(codes.Unauthenticated, "invalid challenge token or code")
+		}
+		return nil, status.Error(codes.Internal, "failed to complete 2fa login")
+	}
+
+	return &user.LoginResponse{
+		AccessToken:  response.Token,
+		RefreshToken: response.RefreshToken,
+		UserId:       response.User.ID,
+	}, nil
+}
+
+func (h *GRPCHandler) VerifyEmail(ctx context.Context, req *user.VerifyEmailRequest) (*user.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	if err := h.service.VerifyEmail(ctx, req.Token); err != nil {
+		if errors.Is(err, service.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+		return nil, status.Error(codes.Internal, "failed to verify email")
+	}
+
+	return &user.VerifyEmailResponse{Verified: true}, nil
+}
+
+func (h *GRPCHandler) RequestPasswordReset(ctx context.Context, req *user.RequestPasswordResetRequest) (*user.RequestPasswordResetResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+
+	// Always reports requested=true: whether the email exists is not revealed to the caller.
+	if err := h.service.RequestPasswordReset(ctx, req.Email); err != nil {
+		return nil, status.Error(codes.Internal, "failed to request password reset")
+	}
+
+	return &user.RequestPasswordResetResponse{Requested: true}, nil
+}
+
+func (h *GRPCHandler) ConfirmPasswordReset(ctx context.Context, req *user.ConfirmPasswordResetRequest) (*user.ConfirmPasswordResetResponse, error) {
+	if req.Token == "" || req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "token and new_password are required")
+	}
+
+	if err := h.service.ConfirmPasswordReset(ctx, req.Token, req.NewPassword); err != nil {
+		if errors.Is(err, service.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired token")
+		}
+		return nil, status.Error(codes.Internal, "failed to reset password")
+	}
+
+	return &user.ConfirmPasswordResetResponse{Reset: true}, nil
+}
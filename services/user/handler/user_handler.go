@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/terkoizmy/golearn/services/user/domain"
@@ -75,6 +76,18 @@ func (h *HTTPHandler) Login(c *gin.Context) {
 			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Invalid email or password"})
 			return
 		}
+		if errors.Is(err, service.ErrSSOOnlyAccount) {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "This account signs in via SSO; use the OAuth login flow"})
+			return
+		}
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Error: "Please verify your email before logging in"})
+			return
+		}
+		if errors.Is(err, service.ErrAccountLocked) {
+			c.JSON(http.StatusLocked, domain.ErrorResponse{Error: "Account is temporarily locked due to too many failed login attempts"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to login"})
 		return
 	}
@@ -82,17 +95,82 @@ func (h *HTTPHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// Refresh godoc
+// @Summary Refresh an access token
+// @Description Exchanges a refresh token for a new access/refresh token pair
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body domain.RefreshRequest true "Refresh token"
+// @Success 200 {object} domain.LoginResponse "New token pair issued"
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 401 {object} domain.ErrorResponse "Invalid or expired refresh token"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/refresh [post]
+func (h *HTTPHandler) Refresh(c *gin.Context) {
+	var req domain.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response, err := h.service.RefreshToken(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "Invalid or expired refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revokes the given refresh token
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body domain.LogoutRequest true "Refresh token to revoke"
+// @Success 204
+// @Failure 400 {object} domain.ErrorResponse "Invalid input"
+// @Failure 500 {object} domain.ErrorResponse "Internal server error"
+// @Router /api/v1/logout [post]
+func (h *HTTPHandler) Logout(c *gin.Context) {
+	var req domain.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if err := h.service.Logout(c.Request.Context(), accessToken, req.RefreshToken); err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Error: "Failed to logout"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // GetUser godoc
 // @Summary Get user by ID
 // @Description Get user information by user ID
 // @Tags Users
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "User ID (UUID)"
-// @Success 200 {object} domain.User "User found"
+// @Success 200 {object} domain.UserResponse "User found"
+// @Failure 401 {object} domain.ErrorResponse "Missing or invalid token"
 // @Failure 404 {object} domain.ErrorResponse "User not found"
 // @Failure 500 {object} domain.ErrorResponse "Internal server error"
 // @Router /api/v1/users/{id} [get]
 func (h *HTTPHandler) GetUser(c *gin.Context) {
+	if _, ok := h.authenticatedUserID(c); !ok {
+		return
+	}
+
 	id := c.Param("id")
 
 	user, err := h.service.GetUserByID(c.Request.Context(), id)
@@ -105,5 +183,13 @@ func (h *HTTPHandler) GetUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	// role/totp_enabled/locked_until/email_verified_at are only meant for
+	// the account owner or an admin-scoped endpoint, never a bare lookup
+	// by id — so this always serializes the redacted UserResponse.
+	c.JSON(http.StatusOK, domain.UserResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt,
+	})
 }
@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"context"
+	"log"
+)
+
+// logMailer logs the email that would have been sent instead of actually
+// sending it. It's the default so local development works without SMTP
+// credentials.
+type logMailer struct{}
+
+// NewLogMailer returns a Mailer that logs instead of sending.
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) SendVerificationEmail(ctx context.Context, toEmail, token string) error {
+	log.Printf("📧 [log-mailer] verification email for %s: token=%s", toEmail, token)
+	return nil
+}
+
+func (m *logMailer) SendPasswordResetEmail(ctx context.Context, toEmail, token string) error {
+	log.Printf("📧 [log-mailer] password reset email for %s: token=%s", toEmail, token)
+	return nil
+}
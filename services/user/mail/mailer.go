@@ -0,0 +1,12 @@
+// Package mail sends the transactional emails for the account-lifecycle
+// flows (verification, password reset) behind a pluggable Mailer so tests
+// and local development don't need a real SMTP server.
+package mail
+
+import "context"
+
+// Mailer sends a single transactional email.
+type Mailer interface {
+	SendVerificationEmail(ctx context.Context, toEmail, token string) error
+	SendPasswordResetEmail(ctx context.Context, toEmail, token string) error
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+)
+
+// SMTPConfig holds the connection details for the smtpMailer.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer returns a Mailer that sends through a real SMTP server.
+func NewSMTPMailer(cfg SMTPConfig) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+func (m *smtpMailer) SendVerificationEmail(ctx context.Context, toEmail, token string) error {
+	subject := "Verify your golearn account"
+	body := fmt.Sprintf("Use this token to verify your email: %s", token)
+	return m.send(toEmail, subject, body)
+}
+
+func (m *smtpMailer) SendPasswordResetEmail(ctx context.Context, toEmail, token string) error {
+	subject := "Reset your golearn password"
+	body := fmt.Sprintf("Use this token to reset your password: %s", token)
+	return m.send(toEmail, subject, body)
+}
+
+func (m *smtpMailer) send(toEmail, subject, body string) error {
+	addr := fmt.Sprintf("%s:%s", m.cfg.Host, m.cfg.Port)
+	auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", m.cfg.From, toEmail, subject, body)
+	return smtp.SendMail(addr, auth, m.cfg.From, []string{toEmail}, []byte(msg))
+}
@@ -0,0 +1,75 @@
+// Package middleware provides Gin middleware that authorizes requests using
+// the role/permissions embedded in a validated access token.
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/service"
+)
+
+// authenticate validates the bearer access token on c, writing a 401
+// response and returning false if it's missing or invalid. On success it
+// stashes the caller's user_id/role/permissions on the context for
+// downstream handlers and the RequireRole/RequirePermission checks below.
+func authenticate(c *gin.Context, svc service.UserService) bool {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "authorization token is required"})
+		return false
+	}
+
+	claims, err := svc.ValidateToken(c.Request.Context(), token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, domain.ErrorResponse{Error: "invalid or expired token"})
+		return false
+	}
+
+	c.Set("user_id", claims.UserID)
+	c.Set("role", claims.Role)
+	c.Set("permissions", claims.Permissions)
+	return true
+}
+
+// RequireRole rejects the request with 403 unless the caller's token role
+// is one of roles.
+func RequireRole(svc service.UserService, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authenticate(c, svc) {
+			return
+		}
+
+		role := c.GetString("role")
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, domain.ErrorResponse{Error: "insufficient role"})
+	}
+}
+
+// RequirePermission rejects the request with 403 unless perm is among the
+// permissions embedded in the caller's token.
+func RequirePermission(svc service.UserService, perm string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authenticate(c, svc) {
+			return
+		}
+
+		permissions, _ := c.Get("permissions")
+		for _, p := range permissions.([]string) {
+			if p == perm {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, domain.ErrorResponse{Error: "insufficient permissions"})
+	}
+}
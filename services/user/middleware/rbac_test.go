@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/service"
+)
+
+// fakeUserService implements service.UserService by embedding the interface
+// (nil, panics if called) and overriding only ValidateToken, which is all
+// authenticate needs.
+type fakeUserService struct {
+	service.UserService
+	claims *domain.TokenClaims
+	err    error
+}
+
+func (f *fakeUserService) ValidateToken(ctx context.Context, token string) (*domain.TokenClaims, error) {
+	return f.claims, f.err
+}
+
+func newTestContext(token string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	c.Request = req
+	return c, w
+}
+
+func TestRequireRoleRejectsMissingToken(t *testing.T) {
+	c, w := newTestContext("")
+
+	RequireRole(&fakeUserService{}, "admin")(c)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("RequireRole() status = %d, want 401 for a missing token", w.Code)
+	}
+}
+
+func TestRequireRoleRejectsWrongRole(t *testing.T) {
+	c, w := newTestContext("token")
+	svc := &fakeUserService{claims: &domain.TokenClaims{UserID: "user-1", Role: "user"}}
+
+	RequireRole(svc, "admin")(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("RequireRole() status = %d, want 403 for an insufficient role", w.Code)
+	}
+}
+
+func TestRequireRoleAllowsMatchingRole(t *testing.T) {
+	c, w := newTestContext("token")
+	svc := &fakeUserService{claims: &domain.TokenClaims{UserID: "user-1", Role: "admin"}}
+
+	RequireRole(svc, "admin")(c)
+
+	if c.IsAborted() {
+		t.Fatalf("RequireRole() aborted the request despite a matching role")
+	}
+	if c.GetString("role") != "admin" {
+		t.Fatalf("RequireRole() did not stash the role on the context")
+	}
+}
+
+func TestRequirePermissionRejectsMissingPermission(t *testing.T) {
+	c, w := newTestContext("token")
+	svc := &fakeUserService{claims: &domain.TokenClaims{UserID: "user-1", Permissions: []string{"users:read"}}}
+
+	RequirePermission(svc, "users:write")(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("RequirePermission() status = %d, want 403 for a missing permission", w.Code)
+	}
+}
+
+func TestRequirePermissionAllowsGrantedPermission(t *testing.T) {
+	c, _ := newTestContext("token")
+	svc := &fakeUserService{claims: &domain.TokenClaims{UserID: "user-1", Permissions: []string{"users:write"}}}
+
+	RequirePermission(svc, "users:write")(c)
+
+	if c.IsAborted() {
+		t.Fatalf("RequirePermission() aborted the request despite a granted permission")
+	}
+}
@@ -0,0 +1,113 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	githubendpoint "golang.org/x/oauth2/endpoints"
+)
+
+const (
+	githubUserURL       = "https://api.github.com/user"
+	githubUserEmailsURL = "https://api.github.com/user/emails"
+)
+
+type githubProvider struct {
+	cfg *oauth2.Config
+}
+
+// NewGitHubProvider builds the GitHub OAuth2 provider from client
+// credentials and scopes loaded via internal/config.
+func NewGitHubProvider(clientID, clientSecret, redirectURL string, scopes []string) Provider {
+	return &githubProvider{
+		cfg: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       scopes,
+			Endpoint:     githubendpoint.GitHub,
+		},
+	}
+}
+
+func (p *githubProvider) Name() string { return "github" }
+
+func (p *githubProvider) AuthCodeURL(state string) string {
+	return p.cfg.AuthCodeURL(state)
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code string) (string, error) {
+	token, err := p.cfg.Exchange(ctx, code)
+	if err != nil {
+		return "", fmt.Errorf("github: failed to exchange code: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+func (p *githubProvider) FetchUserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	var profile struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := githubGet(ctx, accessToken, githubUserURL, &profile); err != nil {
+		return nil, fmt.Errorf("github: failed to fetch profile: %w", err)
+	}
+
+	// The profile's email field carries no verified signal, so the
+	// dedicated emails endpoint is the only source we trust for it. An
+	// unverified primary address can still create a new account but is
+	// never linked onto an existing one (see UpsertOAuthUser).
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := githubGet(ctx, accessToken, githubUserEmailsURL, &emails); err != nil {
+		return nil, fmt.Errorf("github: failed to fetch emails: %w", err)
+	}
+
+	var email string
+	var emailVerified bool
+	for _, e := range emails {
+		if e.Primary {
+			email = e.Email
+			emailVerified = e.Verified
+			break
+		}
+	}
+
+	name := profile.Name
+	if name == "" {
+		name = profile.Login
+	}
+
+	return &UserInfo{Subject: strconv.FormatInt(profile.ID, 10), Email: email, EmailVerified: emailVerified, Name: name}, nil
+}
+
+func githubGet(ctx context.Context, accessToken, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request to %s failed with status %d: %s", url, resp.StatusCode, body)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,77 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const googleUserInfoURL = "https://www.googleapis.com/oauth2/v3/userinfo"
+
+type googleProvider struct {
+	cfg *oauth2.Config
+}
+
+// NewGoogleProvider builds the Google OAuth2 provider from client
+// credentials and scopes loaded via internal/config.
+func NewGoogleProvider(clientID, clientSecret, redirectURL string, scopes []string) Provider {
+	return &googleProvider{
+		cfg: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       scopes,
+			Endpoint:     google.Endpoint,
+		},
+	}
+}
+
+func (p *googleProvider) Name() string { return "google" }
+
+func (p *googleProvider) AuthCodeURL(state string) string {
+	return p.cfg.AuthCodeURL(state, oauth2.AccessTypeOffline)
+}
+
+func (p *googleProvider) Exchange(ctx context.Context, code string) (string, error) {
+	token, err := p.cfg.Exchange(ctx, code)
+	if err != nil {
+		return "", fmt.Errorf("google: failed to exchange code: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+func (p *googleProvider) FetchUserInfo(ctx context.Context, accessToken string) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleUserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google: failed to fetch userinfo: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("google: userinfo request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var payload struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("google: failed to decode userinfo: %w", err)
+	}
+
+	return &UserInfo{Subject: payload.Sub, Email: payload.Email, EmailVerified: payload.EmailVerified, Name: payload.Name}, nil
+}
@@ -0,0 +1,48 @@
+// Package oauth implements the OAuth2/OIDC authorization-code flow used for
+// SSO login, with one Provider per identity provider (Google, GitHub, and a
+// generic OIDC issuer).
+package oauth
+
+import "context"
+
+// UserInfo is the normalized identity golearn cares about, regardless of
+// which provider produced it.
+type UserInfo struct {
+	// Subject is the provider's stable, unique identifier for the user.
+	Subject string
+	Email   string
+	Name    string
+	// EmailVerified reports whether the provider itself attests that Email
+	// has been verified. Linking an OAuth identity onto an existing
+	// password account must never happen on an unverified email, or an
+	// attacker who controls an unverified address could take over the
+	// account it belongs to.
+	EmailVerified bool
+}
+
+// Provider drives the authorization-code flow for a single SSO provider.
+type Provider interface {
+	// Name is the provider key used in routes and the oauth_provider column,
+	// e.g. "google", "github", "oidc".
+	Name() string
+	// AuthCodeURL builds the URL to redirect the user to, embedding state
+	// for CSRF protection.
+	AuthCodeURL(state string) string
+	// Exchange trades an authorization code for the credential
+	// FetchUserInfo takes: an access token, or for the generic OIDC
+	// provider the raw ID token.
+	Exchange(ctx context.Context, code string) (string, error)
+	// FetchUserInfo retrieves the authenticated user's profile using the
+	// credential returned by Exchange.
+	FetchUserInfo(ctx context.Context, accessToken string) (*UserInfo, error)
+}
+
+// Registry looks up a configured Provider by name.
+type Registry map[string]Provider
+
+// Get returns the provider registered under name, or false if none is
+// configured (e.g. its client ID was never set).
+func (r Registry) Get(name string) (Provider, bool) {
+	p, ok := r[name]
+	return p, ok
+}
@@ -0,0 +1,35 @@
+package oauth
+
+import (
+	"context"
+	"log"
+
+	"github.com/terkoizmy/golearn/internal/config"
+)
+
+// NewRegistry builds a Provider for every SSO provider configured in cfg.
+// A provider whose OIDC discovery fails is logged and skipped rather than
+// failing the whole service, since the other providers (or password login)
+// may still work fine.
+func NewRegistry(ctx context.Context, cfg map[string]config.OAuthProviderConfig) Registry {
+	registry := Registry{}
+
+	if google, ok := cfg["google"]; ok {
+		registry["google"] = NewGoogleProvider(google.ClientID, google.ClientSecret, google.RedirectURL, google.Scopes)
+	}
+
+	if github, ok := cfg["github"]; ok {
+		registry["github"] = NewGitHubProvider(github.ClientID, github.ClientSecret, github.RedirectURL, github.Scopes)
+	}
+
+	if generic, ok := cfg["oidc"]; ok {
+		provider, err := NewOIDCProvider(ctx, generic.IssuerURL, generic.ClientID, generic.ClientSecret, generic.RedirectURL, generic.Scopes)
+		if err != nil {
+			log.Printf("oauth: skipping generic OIDC provider: %v", err)
+		} else {
+			registry["oidc"] = provider
+		}
+	}
+
+	return registry
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryLimiter is the default Limiter backend: fine for local development
+// and single-instance deployments, but each replica counts independently.
+type memoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	count   int
+	resetAt time.Time
+}
+
+// NewMemoryLimiter returns an in-process Limiter.
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{buckets: make(map[string]*bucket)}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok || now.After(b.resetAt) {
+		b = &bucket{count: 0, resetAt: now.Add(window)}
+		l.buckets[key] = b
+	}
+
+	b.count++
+	return b.count <= limit, nil
+}
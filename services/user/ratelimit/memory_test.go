@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewMemoryLimiter()
+
+	for i := 0; i < 3; i++ {
+		allowed, err := limiter.Allow(ctx, "key-1", 3, time.Minute)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+		if !allowed {
+			t.Fatalf("Allow() call %d = false, want true within the limit", i+1)
+		}
+	}
+
+	allowed, err := limiter.Allow(ctx, "key-1", 3, time.Minute)
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if allowed {
+		t.Fatalf("Allow() call 4 = true, want false once the limit is exceeded")
+	}
+
+	// A different key has its own independent bucket.
+	allowed, err = limiter.Allow(ctx, "key-2", 3, time.Minute)
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if !allowed {
+		t.Fatalf("Allow() for a fresh key = false, want true")
+	}
+}
+
+func TestMemoryLimiterWindowResets(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewMemoryLimiter()
+
+	if allowed, err := limiter.Allow(ctx, "key-1", 1, 10*time.Millisecond); err != nil || !allowed {
+		t.Fatalf("Allow() = %v, %v, want true, nil", allowed, err)
+	}
+	if allowed, err := limiter.Allow(ctx, "key-1", 1, 10*time.Millisecond); err != nil || allowed {
+		t.Fatalf("Allow() = %v, %v, want false, nil once the limit is hit", allowed, err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if allowed, err := limiter.Allow(ctx, "key-1", 1, 10*time.Millisecond); err != nil || !allowed {
+		t.Fatalf("Allow() after the window elapsed = %v, %v, want true, nil", allowed, err)
+	}
+}
@@ -0,0 +1,21 @@
+// Package ratelimit throttles sensitive unauthenticated endpoints (login,
+// registration) per key behind a pluggable backend, the same way tokenstore
+// pluggably backs refresh tokens: in-process for local dev, Redis for
+// anything that needs to fan out across replicas.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter counts events per key within a fixed window and reports whether
+// the caller is still under the limit. A key is typically a client IP or an
+// email address, so the same Limiter backs both per-IP and per-email
+// throttling — callers just pick what they use as the key.
+type Limiter interface {
+	// Allow records one event for key and reports whether it's still
+	// within limit events per window. Once the window elapses the count
+	// resets.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "golearn:ratelimit:"
+
+type redisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter returns a Limiter backed by Redis, suitable for production
+// and multi-replica deployments where counts need to be shared.
+func NewRedisLimiter(client *redis.Client) Limiter {
+	return &redisLimiter{client: client}
+}
+
+// Allow increments key's counter and sets its expiry on the first event of
+// each window, so the count resets once window elapses.
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	count, err := l.client.Incr(ctx, keyPrefix+key).Result()
+	if err != nil {
+		return false, err
+	}
+	if count == 1 {
+		if err := l.client.Expire(ctx, keyPrefix+key, window).Err(); err != nil {
+			return false, err
+		}
+	}
+	return count <= int64(limit), nil
+}
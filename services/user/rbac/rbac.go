@@ -0,0 +1,37 @@
+// Package rbac maps a domain.Role onto the permissions it carries, so JWTs
+// and the Gin middleware in services/user/middleware can authorize by
+// permission rather than hard-coding role checks everywhere.
+package rbac
+
+import "github.com/terkoizmy/golearn/services/user/domain"
+
+// Registry maps a role to the permissions it grants. Unknown roles resolve
+// to no permissions rather than an error, since a missing role shouldn't
+// be able to escalate access.
+type Registry map[domain.Role][]string
+
+// Permissions returns the permissions granted to role.
+func (r Registry) Permissions(role domain.Role) []string {
+	return r[role]
+}
+
+// Has reports whether role is registered at all, as opposed to merely
+// carrying no permissions.
+func (r Registry) Has(role domain.Role) bool {
+	_, ok := r[role]
+	return ok
+}
+
+// NewRegistry builds the built-in user/admin roles, overlaid with any
+// additional role->permissions entries from config so deployments can add
+// custom roles without a code change.
+func NewRegistry(extra map[string][]string) Registry {
+	reg := Registry{
+		domain.RoleUser:  {},
+		domain.RoleAdmin: {"users:read", "users:write"},
+	}
+	for role, perms := range extra {
+		reg[domain.Role(role)] = perms
+	}
+	return reg
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptRepository tracks consecutive failed logins per user, backing
+// the account-lockout check in service.UserService.Login.
+type LoginAttemptRepository interface {
+	// RecordFailure increments userID's failed-attempt counter, creating
+	// its row if this is the first failure, and returns the new count.
+	RecordFailure(ctx context.Context, userID string) (int, error)
+	// Reset clears userID's failed-attempt counter, called after a
+	// successful login.
+	Reset(ctx context.Context, userID string) error
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+// RecordFailure upserts userID's row with an atomic increment, so
+// concurrent failed logins against the same account can't race a
+// read-modify-write and under-count past loginMaxAttempts.
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, userID string) (int, error) {
+	now := time.Now()
+	attempt := domain.LoginAttempt{UserID: userID, FailedCount: 1, LastFailedAt: now}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failed_count":   gorm.Expr("failed_count + 1"),
+			"last_failed_at": now,
+		}),
+	}).Create(&attempt).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var updated domain.LoginAttempt
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&updated).Error; err != nil {
+		return 0, err
+	}
+	return updated.FailedCount, nil
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.LoginAttempt{}).
+		Where("user_id = ?", userID).
+		Update("failed_count", 0).Error
+}
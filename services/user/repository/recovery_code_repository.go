@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepository persists the single-use backup codes issued when
+// a user confirms TOTP enrollment.
+type RecoveryCodeRepository interface {
+	CreateBatch(ctx context.Context, codes []*domain.RecoveryCode) error
+	GetUnused(ctx context.Context, userID string) ([]*domain.RecoveryCode, error)
+	MarkUsed(ctx context.Context, id string) error
+	DeleteAll(ctx context.Context, userID string) error
+}
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+func (r *recoveryCodeRepository) CreateBatch(ctx context.Context, codes []*domain.RecoveryCode) error {
+	return r.db.WithContext(ctx).Create(&codes).Error
+}
+
+func (r *recoveryCodeRepository) GetUnused(ctx context.Context, userID string) ([]*domain.RecoveryCode, error) {
+	var codes []*domain.RecoveryCode
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Find(&codes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return codes, nil
+}
+
+func (r *recoveryCodeRepository) MarkUsed(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.RecoveryCode{}).
+		Where("id = ?", id).
+		Update("used_at", time.Now()).Error
+}
+
+// DeleteAll removes every recovery code issued to userID, so a disable
+// followed by re-enrollment doesn't leave a prior batch still redeemable.
+func (r *recoveryCodeRepository) DeleteAll(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&domain.RecoveryCode{}).Error
+}
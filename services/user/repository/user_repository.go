@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/terkoizmy/golearn/services/user/domain"
 	"gorm.io/gorm"
@@ -11,12 +12,26 @@ import (
 var (
 	ErrUserNotFound   = errors.New("user not found")
 	ErrDuplicateEmail = errors.New("email already exists")
+	// ErrOAuthEmailNotVerified is returned by UpsertOAuthUser when the
+	// matched row isn't already bound to this exact (provider, subject)
+	// and the caller can't vouch that the email is verified.
+	ErrOAuthEmailNotVerified = errors.New("provider did not report this email as verified")
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetByOAuthIdentity(ctx context.Context, provider, subject string) (*domain.User, error)
+	UpsertOAuthUser(ctx context.Context, user *domain.User, emailVerified bool) error
+	MarkEmailVerified(ctx context.Context, userID string) error
+	UpdatePassword(ctx context.Context, userID, hashedPassword string) error
+	SetTOTPSecret(ctx context.Context, userID, encryptedSecret string) error
+	EnableTOTP(ctx context.Context, userID string) error
+	DisableTOTP(ctx context.Context, userID string) error
+	ListUsers(ctx context.Context) ([]*domain.User, error)
+	UpdateRole(ctx context.Context, userID, role string) error
+	LockUser(ctx context.Context, userID string, until time.Time) error
 }
 
 type userRepository struct {
@@ -62,3 +77,123 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	}
 	return &user, nil
 }
+
+func (r *userRepository) GetByOAuthIdentity(ctx context.Context, provider, subject string) (*domain.User, error) {
+	var user domain.User
+	result := r.db.WithContext(ctx).
+		Where("oauth_provider = ? AND oauth_subject = ?", provider, subject).
+		First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// UpsertOAuthUser creates the user if no row with this email exists yet, or
+// links the OAuth identity onto the existing row otherwise. Linking is only
+// allowed without emailVerified when the row is already bound to this exact
+// (provider, subject) — any other match (a password account, or one linked
+// to a different provider/subject) requires emailVerified, or an attacker
+// who gets a provider to hand back someone else's unverified email could
+// take over their account.
+func (r *userRepository) UpsertOAuthUser(ctx context.Context, user *domain.User, emailVerified bool) error {
+	var existing domain.User
+	result := r.db.WithContext(ctx).Where("email = ?", user.Email).First(&existing)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return r.Create(ctx, user)
+		}
+		return result.Error
+	}
+
+	alreadyLinked := existing.OAuthProvider == user.OAuthProvider && existing.OAuthSubject == user.OAuthSubject
+	if !alreadyLinked && !emailVerified {
+		return ErrOAuthEmailNotVerified
+	}
+
+	existing.OAuthProvider = user.OAuthProvider
+	existing.OAuthSubject = user.OAuthSubject
+	if existing.Name == "" {
+		existing.Name = user.Name
+	}
+	if err := r.db.WithContext(ctx).Save(&existing).Error; err != nil {
+		return err
+	}
+	*user = existing
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userID string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Update("email_verified_at", &now).Error
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Update("password", hashedPassword).Error
+}
+
+// SetTOTPSecret persists a newly enrolled (but not yet confirmed) TOTP
+// secret. It does not touch TOTPEnabled.
+func (r *userRepository) SetTOTPSecret(ctx context.Context, userID, encryptedSecret string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Update("totp_secret", encryptedSecret).Error
+}
+
+func (r *userRepository) EnableTOTP(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Update("totp_enabled", true).Error
+}
+
+// DisableTOTP turns 2FA back off and clears the stored secret so it can't
+// be re-enabled without a fresh enrollment.
+func (r *userRepository) DisableTOTP(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"totp_enabled": false,
+			"totp_secret":  "",
+		}).Error
+}
+
+// ListUsers returns every user, ordered oldest-first. Intended for the
+// admin user-management endpoints, so callers are expected to redact
+// passwords before returning the result further.
+func (r *userRepository) ListUsers(ctx context.Context) ([]*domain.User, error) {
+	var users []*domain.User
+	result := r.db.WithContext(ctx).Order("created_at").Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, userID, role string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Update("role", role).Error
+}
+
+// LockUser rejects login for userID until the given time. The column is
+// not cleared afterwards; service.UserService.Login ignores a LockedUntil
+// that has already passed.
+func (r *userRepository) LockUser(ctx context.Context, userID string, until time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.User{}).
+		Where("id = ?", userID).
+		Update("locked_until", until).Error
+}
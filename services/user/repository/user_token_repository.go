@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenExpired  = errors.New("token has expired")
+	ErrTokenUsed     = errors.New("token has already been used")
+)
+
+// UserTokenRepository persists the single-use tokens backing the
+// email-verification and password-reset flows.
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *domain.UserToken) error
+	// GetValid looks up an unused, unexpired token by its hash and
+	// purpose. Callers should not distinguish "used" from "expired" from
+	// "never existed" beyond logging, since all three mean the token
+	// can't be redeemed.
+	GetValid(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.UserToken, error)
+	MarkUsed(ctx context.Context, id string) error
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *userTokenRepository) GetValid(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	var token domain.UserToken
+	result := r.db.WithContext(ctx).
+		Where("token_hash = ? AND purpose = ?", tokenHash, purpose).
+		First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, ErrTokenNotFound
+		}
+		return nil, result.Error
+	}
+
+	if token.UsedAt != nil {
+		return nil, ErrTokenUsed
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	return &token, nil
+}
+
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserToken{}).
+		Where("id = ?", id).
+		Update("used_at", time.Now()).Error
+}
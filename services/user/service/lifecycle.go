@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/terkoizmy/golearn/internal/util"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/repository"
+)
+
+// sendVerificationEmail issues a single-use verify_email token for user and
+// hands it to the configured Mailer. The raw token is only ever logged/sent
+// here; only its hash is persisted.
+func (s *userService) sendVerificationEmail(ctx context.Context, user *domain.User) error {
+	rawToken, err := util.GenerateRefreshToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	token := &domain.UserToken{
+		UserID:    user.ID,
+		TokenHash: util.HashToken(rawToken),
+		Purpose:   domain.TokenPurposeVerifyEmail,
+		ExpiresAt: time.Now().Add(VerificationTokenTTL),
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return fmt.Errorf("failed to persist verification token: %w", err)
+	}
+
+	return s.mailer.SendVerificationEmail(ctx, user.Email, rawToken)
+}
+
+// VerifyEmail redeems a verify_email token and marks the owning user's
+// email as verified.
+func (s *userService) VerifyEmail(ctx context.Context, rawToken string) error {
+	token, err := s.tokenRepo.GetValid(ctx, util.HashToken(rawToken), domain.TokenPurposeVerifyEmail)
+	if err != nil {
+		return tokenLookupErr(err)
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, token.UserID); err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+
+	return s.tokenRepo.MarkUsed(ctx, token.ID)
+}
+
+// RequestPasswordReset issues a single-use reset_password token for the
+// account with the given email, if one exists. It never reports whether
+// the email was found, so the endpoint can't be used to enumerate
+// accounts.
+func (s *userService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	rawToken, err := util.GenerateRefreshToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	token := &domain.UserToken{
+		UserID:    user.ID,
+		TokenHash: util.HashToken(rawToken),
+		Purpose:   domain.TokenPurposeResetPassword,
+		ExpiresAt: time.Now().Add(PasswordResetTokenTTL),
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return fmt.Errorf("failed to persist reset token: %w", err)
+	}
+
+	return s.mailer.SendPasswordResetEmail(ctx, user.Email, rawToken)
+}
+
+// ConfirmPasswordReset redeems a reset_password token and sets the new
+// password on the owning account.
+func (s *userService) ConfirmPasswordReset(ctx context.Context, rawToken, newPassword string) error {
+	token, err := s.tokenRepo.GetValid(ctx, util.HashToken(rawToken), domain.TokenPurposeResetPassword)
+	if err != nil {
+		return tokenLookupErr(err)
+	}
+
+	hashedPassword, err := util.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.repo.UpdatePassword(ctx, token.UserID, hashedPassword); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return s.tokenRepo.MarkUsed(ctx, token.ID)
+}
+
+// tokenLookupErr normalizes the token repository's not-found/expired/used
+// errors into the single ErrInvalidToken the API surfaces, since none of
+// those distinctions should be exposed to the caller.
+func tokenLookupErr(err error) error {
+	if errors.Is(err, repository.ErrTokenNotFound) || errors.Is(err, repository.ErrTokenExpired) || errors.Is(err, repository.ErrTokenUsed) {
+		return ErrInvalidToken
+	}
+	return fmt.Errorf("failed to look up token: %w", err)
+}
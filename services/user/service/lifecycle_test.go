@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terkoizmy/golearn/internal/util"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/repository"
+)
+
+// fakeUserTokenRepository is an in-memory stand-in for
+// repository.UserTokenRepository that reproduces the real repository's
+// validity rules (purpose match, not used, not expired), so it can back a
+// behavioral test of replay/expiry without a database.
+type fakeUserTokenRepository struct {
+	tokens map[string]*domain.UserToken
+}
+
+func newFakeUserTokenRepository() *fakeUserTokenRepository {
+	return &fakeUserTokenRepository{tokens: make(map[string]*domain.UserToken)}
+}
+
+func (f *fakeUserTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	if token.ID == "" {
+		token.ID = token.TokenHash
+	}
+	f.tokens[token.ID] = token
+	return nil
+}
+
+func (f *fakeUserTokenRepository) GetValid(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	for _, token := range f.tokens {
+		if token.TokenHash != tokenHash || token.Purpose != purpose {
+			continue
+		}
+		if token.UsedAt != nil {
+			return nil, repository.ErrTokenUsed
+		}
+		if time.Now().After(token.ExpiresAt) {
+			return nil, repository.ErrTokenExpired
+		}
+		return token, nil
+	}
+	return nil, repository.ErrTokenNotFound
+}
+
+func (f *fakeUserTokenRepository) MarkUsed(ctx context.Context, id string) error {
+	now := time.Now()
+	f.tokens[id].UsedAt = &now
+	return nil
+}
+
+func (f *fakeUserRepository) MarkEmailVerified(ctx context.Context, userID string) error {
+	now := time.Now()
+	f.user.EmailVerifiedAt = &now
+	return nil
+}
+
+func (f *fakeUserRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	f.user.Password = hashedPassword
+	return nil
+}
+
+func TestVerifyEmailRejectsReplayAndExpiry(t *testing.T) {
+	user := &domain.User{ID: "user-1", Email: "user@example.com"}
+	tokenRepo := newFakeUserTokenRepository()
+
+	rawToken := "raw-verification-token"
+	if err := tokenRepo.Create(context.Background(), &domain.UserToken{
+		ID:        "tok-1",
+		UserID:    user.ID,
+		TokenHash: util.HashToken(rawToken),
+		Purpose:   domain.TokenPurposeVerifyEmail,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	s := &userService{repo: &fakeUserRepository{user: user}, tokenRepo: tokenRepo}
+
+	if err := s.VerifyEmail(context.Background(), rawToken); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if user.EmailVerifiedAt == nil {
+		t.Fatalf("VerifyEmail() did not mark the user's email verified")
+	}
+
+	// Replaying the same token must fail now that it's used.
+	if err := s.VerifyEmail(context.Background(), rawToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("VerifyEmail() on a replayed token error = %v, want ErrInvalidToken", err)
+	}
+
+	// An expired, never-used token must also be rejected.
+	expiredRaw := "raw-expired-token"
+	if err := tokenRepo.Create(context.Background(), &domain.UserToken{
+		ID:        "tok-2",
+		UserID:    user.ID,
+		TokenHash: util.HashToken(expiredRaw),
+		Purpose:   domain.TokenPurposeVerifyEmail,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := s.VerifyEmail(context.Background(), expiredRaw); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("VerifyEmail() on an expired token error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestConfirmPasswordResetRejectsReplay(t *testing.T) {
+	user := &domain.User{ID: "user-1", Email: "user@example.com", Password: "old-hash"}
+	tokenRepo := newFakeUserTokenRepository()
+
+	rawToken := "raw-reset-token"
+	if err := tokenRepo.Create(context.Background(), &domain.UserToken{
+		ID:        "tok-1",
+		UserID:    user.ID,
+		TokenHash: util.HashToken(rawToken),
+		Purpose:   domain.TokenPurposeResetPassword,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	s := &userService{repo: &fakeUserRepository{user: user}, tokenRepo: tokenRepo}
+
+	if err := s.ConfirmPasswordReset(context.Background(), rawToken, "new-password"); err != nil {
+		t.Fatalf("ConfirmPasswordReset() error = %v", err)
+	}
+	if user.Password == "old-hash" {
+		t.Fatalf("ConfirmPasswordReset() did not update the password")
+	}
+
+	if err := s.ConfirmPasswordReset(context.Background(), rawToken, "another-password"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("ConfirmPasswordReset() on a replayed token error = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/repository"
+)
+
+// fakeLoginAttemptRepository is an in-memory stand-in for
+// repository.LoginAttemptRepository.
+type fakeLoginAttemptRepository struct {
+	counts map[string]int
+}
+
+func newFakeLoginAttemptRepository() *fakeLoginAttemptRepository {
+	return &fakeLoginAttemptRepository{counts: make(map[string]int)}
+}
+
+func (f *fakeLoginAttemptRepository) RecordFailure(ctx context.Context, userID string) (int, error) {
+	f.counts[userID]++
+	return f.counts[userID], nil
+}
+
+func (f *fakeLoginAttemptRepository) Reset(ctx context.Context, userID string) error {
+	f.counts[userID] = 0
+	return nil
+}
+
+// lockingUserRepository records the userID/until LockUser was called with,
+// satisfying repository.UserRepository via embedding for everything else.
+type lockingUserRepository struct {
+	repository.UserRepository
+	lockedUserID string
+	lockedUntil  time.Time
+}
+
+func (r *lockingUserRepository) LockUser(ctx context.Context, userID string, until time.Time) error {
+	r.lockedUserID = userID
+	r.lockedUntil = until
+	return nil
+}
+
+func TestRecordLoginFailureLocksAccountAfterMaxAttempts(t *testing.T) {
+	loginAttempts := newFakeLoginAttemptRepository()
+	repo := &lockingUserRepository{}
+	s := &userService{
+		repo:              repo,
+		loginAttempts:     loginAttempts,
+		loginMaxAttempts:  3,
+		loginLockDuration: 15 * time.Minute,
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := s.recordLoginFailure(context.Background(), "user-1"); err != nil {
+			t.Fatalf("recordLoginFailure() error = %v", err)
+		}
+		if repo.lockedUserID != "" {
+			t.Fatalf("recordLoginFailure() locked the account after %d failures, want it to wait for loginMaxAttempts", i+1)
+		}
+	}
+
+	if err := s.recordLoginFailure(context.Background(), "user-1"); err != nil {
+		t.Fatalf("recordLoginFailure() error = %v", err)
+	}
+	if repo.lockedUserID != "user-1" {
+		t.Fatalf("recordLoginFailure() did not lock user-1 after reaching loginMaxAttempts")
+	}
+	if !repo.lockedUntil.After(time.Now()) {
+		t.Fatalf("recordLoginFailure() lockedUntil = %v, want a time in the future", repo.lockedUntil)
+	}
+}
+
+func TestLoginRejectsLockedAccount(t *testing.T) {
+	lockedUntil := time.Now().Add(5 * time.Minute)
+	user := &domain.User{ID: "user-1", Email: "locked@example.com", Password: "irrelevant", LockedUntil: &lockedUntil}
+
+	s := &userService{repo: &fakeUserRepository{user: user}}
+
+	_, err := s.Login(context.Background(), &domain.LoginRequest{Email: user.Email, Password: "whatever"})
+	if !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("Login() on a locked account error = %v, want ErrAccountLocked", err)
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+)
+
+// ListUsers returns every user with their passwords cleared, for the
+// admin-only user-management endpoints.
+func (s *userService) ListUsers(ctx context.Context) ([]*domain.User, error) {
+	users, err := s.repo.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	for _, u := range users {
+		u.Password = ""
+	}
+	return users, nil
+}
+
+// UpdateUserRole assigns role to userID, rejecting roles the rbac registry
+// doesn't know about so a typo can't silently grant an empty permission set.
+func (s *userService) UpdateUserRole(ctx context.Context, userID, role string) error {
+	if !s.roles.Has(domain.Role(role)) {
+		return ErrInvalidRole
+	}
+
+	if err := s.repo.UpdateRole(ctx, userID, role); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/rbac"
+)
+
+func (f *fakeUserRepository) UpdateRole(ctx context.Context, userID, role string) error {
+	f.user.Role = domain.Role(role)
+	return nil
+}
+
+func TestUpdateUserRoleRejectsUnknownRole(t *testing.T) {
+	user := &domain.User{ID: "user-1", Role: domain.RoleUser}
+	s := &userService{repo: &fakeUserRepository{user: user}, roles: rbac.NewRegistry(nil)}
+
+	if err := s.UpdateUserRole(context.Background(), user.ID, "superadmin"); !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("UpdateUserRole() error = %v, want ErrInvalidRole", err)
+	}
+	if user.Role != domain.RoleUser {
+		t.Fatalf("UpdateUserRole() changed the role despite rejecting it, role = %v", user.Role)
+	}
+}
+
+func TestUpdateUserRoleAcceptsKnownRole(t *testing.T) {
+	user := &domain.User{ID: "user-1", Role: domain.RoleUser}
+	s := &userService{repo: &fakeUserRepository{user: user}, roles: rbac.NewRegistry(nil)}
+
+	if err := s.UpdateUserRole(context.Background(), user.ID, string(domain.RoleAdmin)); err != nil {
+		t.Fatalf("UpdateUserRole() error = %v", err)
+	}
+	if user.Role != domain.RoleAdmin {
+		t.Fatalf("UpdateUserRole() role = %v, want RoleAdmin", user.Role)
+	}
+}
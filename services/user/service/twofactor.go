@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/terkoizmy/golearn/internal/util"
+	"github.com/terkoizmy/golearn/services/user/domain"
+)
+
+// recoveryCodeCount is how many single-use recovery codes are issued when
+// a user confirms TOTP enrollment.
+const recoveryCodeCount = 10
+
+// EnrollTOTP generates a new TOTP secret for userID and stores it
+// encrypted, without enabling 2FA yet — enrollment only takes effect once
+// the user proves they can generate a valid code via ConfirmTOTP.
+func (s *userService) EnrollTOTP(ctx context.Context, userID string) (*domain.TOTPEnrollResponse, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	encrypted, err := util.EncryptSecret(secret, s.totpEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	if err := s.repo.SetTOTPSecret(ctx, userID, encrypted); err != nil {
+		return nil, fmt.Errorf("failed to persist totp secret: %w", err)
+	}
+
+	return &domain.TOTPEnrollResponse{
+		Secret:     secret,
+		OTPAuthURL: util.TOTPAuthURL("golearn", user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP verifies the first code from the user's authenticator app and
+// flips TOTPEnabled on, issuing a fresh batch of recovery codes. The raw
+// codes are only ever returned here; only their bcrypt hashes are
+// persisted.
+func (s *userService) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := util.DecryptSecret(user.TOTPSecret, s.totpEncryptionKey)
+	if err != nil {
+		return nil, ErrInvalidTOTPCode
+	}
+	if !util.ValidateTOTPCode(secret, code, time.Now()) {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	if err := s.repo.EnableTOTP(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	return s.issueRecoveryCodes(ctx, userID)
+}
+
+// DisableTOTP verifies a current code and turns 2FA back off, clearing the
+// stored secret so a fresh enrollment is required to turn it back on.
+func (s *userService) DisableTOTP(ctx context.Context, userID, code string) error {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+
+	secret, err := util.DecryptSecret(user.TOTPSecret, s.totpEncryptionKey)
+	if err != nil {
+		return ErrInvalidTOTPCode
+	}
+	if !util.ValidateTOTPCode(secret, code, time.Now()) {
+		return ErrInvalidTOTPCode
+	}
+
+	if err := s.repo.DisableTOTP(ctx, userID); err != nil {
+		return err
+	}
+
+	// A future re-enrollment issues an entirely new batch, so the old one
+	// must not stay redeemable in the meantime.
+	return s.recoveryCodes.DeleteAll(ctx, userID)
+}
+
+// LoginWithTOTP completes a two-step login: it redeems the short-lived
+// challenge token Login issued, verifies the second factor (a TOTP code or
+// a single-use recovery code), and issues the real access/refresh pair.
+func (s *userService) LoginWithTOTP(ctx context.Context, challengeToken, code string) (*domain.LoginResponse, error) {
+	claims, err := util.ValidateJWT(challengeToken, s.jwtSecret)
+	if err != nil || claims.Purpose != util.PurposeTOTPChallenge {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	user, err := s.repo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrInvalidChallengeToken
+	}
+
+	secret, err := util.DecryptSecret(user.TOTPSecret, s.totpEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	if !util.ValidateTOTPCode(secret, code, time.Now()) && !s.redeemRecoveryCode(ctx, user.ID, code) {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	return s.issueSession(ctx, user)
+}
+
+// issueRecoveryCodes generates recoveryCodeCount single-use recovery codes
+// for userID, storing only their bcrypt hashes and returning the raw codes
+// once so the user can save them.
+func (s *userService) issueRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	rawCodes := make([]string, recoveryCodeCount)
+	records := make([]*domain.RecoveryCode, recoveryCodeCount)
+	for i := range rawCodes {
+		raw, err := util.GenerateRefreshToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		raw = raw[:10]
+
+		hashed, err := util.HashPassword(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+
+		rawCodes[i] = raw
+		records[i] = &domain.RecoveryCode{UserID: userID, CodeHash: hashed}
+	}
+
+	if err := s.recoveryCodes.CreateBatch(ctx, records); err != nil {
+		return nil, fmt.Errorf("failed to persist recovery codes: %w", err)
+	}
+
+	return rawCodes, nil
+}
+
+// redeemRecoveryCode checks code against userID's unused recovery codes,
+// marking a match used so it can't be replayed.
+func (s *userService) redeemRecoveryCode(ctx context.Context, userID, code string) bool {
+	codes, err := s.recoveryCodes.GetUnused(ctx, userID)
+	if err != nil {
+		return false
+	}
+
+	for _, rc := range codes {
+		if util.CheckPassword(code, rc.CodeHash) {
+			_ = s.recoveryCodes.MarkUsed(ctx, rc.ID)
+			return true
+		}
+	}
+	return false
+}
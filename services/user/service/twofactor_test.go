@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/terkoizmy/golearn/internal/util"
+	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/repository"
+)
+
+const testTOTPEncryptionKey = "dev-totp-encryption-key-32bytes!"
+
+// fakeUserRepository implements repository.UserRepository by embedding the
+// interface (nil, panics if called) and overriding only what each test
+// exercises.
+type fakeUserRepository struct {
+	repository.UserRepository
+	user *domain.User
+}
+
+func (f *fakeUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return f.user, nil
+}
+
+func (f *fakeUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return f.user, nil
+}
+
+func (f *fakeUserRepository) DisableTOTP(ctx context.Context, userID string) error {
+	f.user.TOTPEnabled = false
+	return nil
+}
+
+// fakeRecoveryCodeRepository is an in-memory stand-in for
+// repository.RecoveryCodeRepository.
+type fakeRecoveryCodeRepository struct {
+	codes map[string]*domain.RecoveryCode
+}
+
+func newFakeRecoveryCodeRepository() *fakeRecoveryCodeRepository {
+	return &fakeRecoveryCodeRepository{codes: make(map[string]*domain.RecoveryCode)}
+}
+
+func (f *fakeRecoveryCodeRepository) CreateBatch(ctx context.Context, codes []*domain.RecoveryCode) error {
+	for _, c := range codes {
+		f.codes[c.ID] = c
+	}
+	return nil
+}
+
+func (f *fakeRecoveryCodeRepository) GetUnused(ctx context.Context, userID string) ([]*domain.RecoveryCode, error) {
+	var out []*domain.RecoveryCode
+	for _, c := range f.codes {
+		if c.UserID == userID && c.UsedAt == nil {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRecoveryCodeRepository) MarkUsed(ctx context.Context, id string) error {
+	now := time.Now()
+	f.codes[id].UsedAt = &now
+	return nil
+}
+
+func (f *fakeRecoveryCodeRepository) DeleteAll(ctx context.Context, userID string) error {
+	for id, c := range f.codes {
+		if c.UserID == userID {
+			delete(f.codes, id)
+		}
+	}
+	return nil
+}
+
+func newRecoveryCode(userID, rawCode string) *domain.RecoveryCode {
+	hashed, err := util.HashPassword(rawCode)
+	if err != nil {
+		panic(err)
+	}
+	return &domain.RecoveryCode{ID: rawCode, UserID: userID, CodeHash: hashed}
+}
+
+// currentTOTPCode brute-forces the 6-digit code ValidateTOTPCode currently
+// accepts for secret, without reaching into util's unexported HOTP helper.
+func currentTOTPCode(t *testing.T, secret string) string {
+	t.Helper()
+	now := time.Now()
+	for i := 0; i < 1_000_000; i++ {
+		code := fmt.Sprintf("%06d", i)
+		if util.ValidateTOTPCode(secret, code, now) {
+			return code
+		}
+	}
+	t.Fatalf("no 6-digit code validated against secret %q", secret)
+	return ""
+}
+
+func TestRedeemRecoveryCode(t *testing.T) {
+	recoveryCodes := newFakeRecoveryCodeRepository()
+	code := newRecoveryCode("user-1", "abc123")
+	if err := recoveryCodes.CreateBatch(context.Background(), []*domain.RecoveryCode{code}); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	s := &userService{recoveryCodes: recoveryCodes}
+
+	if !s.redeemRecoveryCode(context.Background(), "user-1", "abc123") {
+		t.Fatalf("redeemRecoveryCode() = false on first use, want true")
+	}
+	if s.redeemRecoveryCode(context.Background(), "user-1", "abc123") {
+		t.Fatalf("redeemRecoveryCode() = true on replay, want false (single-use)")
+	}
+}
+
+func TestDisableTOTPPurgesRecoveryCodes(t *testing.T) {
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	encrypted, err := util.EncryptSecret(secret, testTOTPEncryptionKey)
+	if err != nil {
+		t.Fatalf("EncryptSecret() error = %v", err)
+	}
+	user := &domain.User{ID: "user-1", TOTPSecret: encrypted, TOTPEnabled: true}
+
+	recoveryCodes := newFakeRecoveryCodeRepository()
+	code := newRecoveryCode(user.ID, "leaked-code")
+	if err := recoveryCodes.CreateBatch(context.Background(), []*domain.RecoveryCode{code}); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	s := &userService{
+		repo:              &fakeUserRepository{user: user},
+		recoveryCodes:     recoveryCodes,
+		totpEncryptionKey: testTOTPEncryptionKey,
+	}
+
+	if err := s.DisableTOTP(context.Background(), user.ID, currentTOTPCode(t, secret)); err != nil {
+		t.Fatalf("DisableTOTP() error = %v", err)
+	}
+
+	remaining, err := recoveryCodes.GetUnused(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("GetUnused() error = %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("GetUnused() after DisableTOTP = %d codes, want 0 (purged)", len(remaining))
+	}
+}
@@ -4,32 +4,104 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/terkoizmy/golearn/internal/util"
 	"github.com/terkoizmy/golearn/services/user/domain"
+	"github.com/terkoizmy/golearn/services/user/mail"
+	"github.com/terkoizmy/golearn/services/user/rbac"
 	"github.com/terkoizmy/golearn/services/user/repository"
+	"github.com/terkoizmy/golearn/services/user/tokenstore"
+)
+
+// RefreshTokenTTL is how long a refresh token remains valid if never used.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// VerificationTokenTTL and PasswordResetTokenTTL bound how long an
+// email-verification or password-reset token can be redeemed for.
+const (
+	VerificationTokenTTL  = 24 * time.Hour
+	PasswordResetTokenTTL = 1 * time.Hour
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidCredentials    = errors.New("invalid email or password")
+	ErrSSOOnlyAccount        = errors.New("this account signs in via SSO and has no password")
+	ErrInvalidRefreshToken   = errors.New("invalid or expired refresh token")
+	ErrTokenRevoked          = errors.New("token has been revoked")
+	ErrEmailNotVerified      = errors.New("email address has not been verified")
+	ErrInvalidToken          = errors.New("invalid or expired token")
+	ErrTOTPAlreadyEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled        = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTOTPCode       = errors.New("invalid two-factor authentication code")
+	ErrInvalidChallengeToken = errors.New("invalid or expired challenge token")
+	ErrInvalidRole           = errors.New("unknown role")
+	ErrAccountLocked         = errors.New("account is temporarily locked due to too many failed login attempts")
+	ErrOAuthEmailNotVerified = errors.New("provider did not report this email as verified")
 )
 
 type UserService interface {
 	Register(ctx context.Context, req *domain.RegisterRequest) (*domain.User, error)
 	Login(ctx context.Context, req *domain.LoginRequest) (*domain.LoginResponse, error)
+	LoginWithOAuth(ctx context.Context, provider, subject, email, name string, emailVerified bool) (*domain.LoginResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*domain.LoginResponse, error)
+	Logout(ctx context.Context, accessToken, refreshToken string) error
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
-	ValidateToken(ctx context.Context, token string) (string, error)
+	ValidateToken(ctx context.Context, token string) (*domain.TokenClaims, error)
+	ListUsers(ctx context.Context) ([]*domain.User, error)
+	UpdateUserRole(ctx context.Context, userID, role string) error
+	VerifyEmail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	EnrollTOTP(ctx context.Context, userID string) (*domain.TOTPEnrollResponse, error)
+	ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID, code string) error
+	LoginWithTOTP(ctx context.Context, challengeToken, code string) (*domain.LoginResponse, error)
 }
 
 type userService struct {
-	repo      repository.UserRepository
-	jwtSecret string
+	repo                 repository.UserRepository
+	tokenRepo            repository.UserTokenRepository
+	recoveryCodes        repository.RecoveryCodeRepository
+	loginAttempts        repository.LoginAttemptRepository
+	tokens               tokenstore.Store
+	mailer               mail.Mailer
+	roles                rbac.Registry
+	jwtSecret            string
+	requireVerifiedEmail bool
+	totpEncryptionKey    string
+	loginMaxAttempts     int
+	loginLockDuration    time.Duration
 }
 
-func NewUserService(repo repository.UserRepository, jwtSecret string) UserService {
+func NewUserService(
+	repo repository.UserRepository,
+	tokenRepo repository.UserTokenRepository,
+	recoveryCodes repository.RecoveryCodeRepository,
+	loginAttempts repository.LoginAttemptRepository,
+	tokens tokenstore.Store,
+	mailer mail.Mailer,
+	roles rbac.Registry,
+	jwtSecret string,
+	requireVerifiedEmail bool,
+	totpEncryptionKey string,
+	loginMaxAttempts int,
+	loginLockDuration time.Duration,
+) UserService {
 	return &userService{
-		repo:      repo,
-		jwtSecret: jwtSecret,
+		repo:                 repo,
+		tokenRepo:            tokenRepo,
+		recoveryCodes:        recoveryCodes,
+		loginAttempts:        loginAttempts,
+		tokens:               tokens,
+		mailer:               mailer,
+		roles:                roles,
+		jwtSecret:            jwtSecret,
+		requireVerifiedEmail: requireVerifiedEmail,
+		totpEncryptionKey:    totpEncryptionKey,
+		loginMaxAttempts:     loginMaxAttempts,
+		loginLockDuration:    loginLockDuration,
 	}
 }
 
@@ -53,6 +125,10 @@ func (s *userService) Register(ctx context.Context, req *domain.RegisterRequest)
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to send verification email: %w", err)
+	}
+
 	// Clear password before returning
 	user.Password = ""
 	return user, nil
@@ -68,26 +144,135 @@ func (s *userService) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	if user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
+		return nil, ErrAccountLocked
+	}
+
+	// SSO-only accounts have no password to check against
+	if user.Password == "" {
+		return nil, ErrSSOOnlyAccount
+	}
+
 	// Verify password
 	if !util.CheckPassword(req.Password, user.Password) {
+		if err := s.recordLoginFailure(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to record login failure: %w", err)
+		}
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate JWT token
-	token, err := util.GenerateJWT(user.ID, user.Email, s.jwtSecret)
+	if s.requireVerifiedEmail && user.EmailVerifiedAt == nil {
+		return nil, ErrEmailNotVerified
+	}
+
+	if err := s.loginAttempts.Reset(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+
+	// TOTP-enabled accounts don't get a full session yet: hand back a
+	// short-lived challenge token and make the caller complete
+	// LoginWithTOTP instead.
+	if user.TOTPEnabled {
+		challengeToken, err := util.GenerateChallengeJWT(user.ID, s.jwtSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate challenge token: %w", err)
+		}
+		return &domain.LoginResponse{ChallengeToken: challengeToken}, nil
+	}
+
+	return s.issueSession(ctx, user)
+}
+
+// LoginWithOAuth upserts a user linked to the given SSO identity and issues
+// a golearn session for it, the same way Login does for password accounts.
+// emailVerified must come from the provider itself: an unverified email is
+// only allowed to create a brand-new account or complete a login this exact
+// (provider, subject) already owns, never to attach onto a password account
+// or an account linked to a different provider, or an attacker could take
+// over that account by getting a provider to hand back a matching but
+// unverified address.
+func (s *userService) LoginWithOAuth(ctx context.Context, provider, subject, email, name string, emailVerified bool) (*domain.LoginResponse, error) {
+	user := &domain.User{
+		Email:         email,
+		Name:          name,
+		OAuthProvider: provider,
+		OAuthSubject:  subject,
+	}
+
+	if err := s.repo.UpsertOAuthUser(ctx, user, emailVerified); err != nil {
+		if errors.Is(err, repository.ErrOAuthEmailNotVerified) {
+			return nil, ErrOAuthEmailNotVerified
+		}
+		return nil, fmt.Errorf("failed to upsert oauth user: %w", err)
+	}
+
+	if user.TOTPEnabled {
+		challengeToken, err := util.GenerateChallengeJWT(user.ID, s.jwtSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate challenge token: %w", err)
+		}
+		return &domain.LoginResponse{ChallengeToken: challengeToken}, nil
+	}
+
+	return s.issueSession(ctx, user)
+}
+
+// RefreshToken rotates a refresh token for a new access/refresh pair. Reuse
+// of a token that was already rotated away revokes its entire family (the
+// signal that it was stolen) and is reported back as
+// ErrInvalidRefreshToken.
+func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*domain.LoginResponse, error) {
+	newToken, err := util.GenerateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	rt, err := s.tokens.Rotate(ctx, refreshToken, newToken, RefreshTokenTTL)
+	if err != nil {
+		if errors.Is(err, tokenstore.ErrReused) {
+			_ = s.tokens.RevokeFamily(ctx, rt.FamilyID)
+			return nil, ErrInvalidRefreshToken
+		}
+		if errors.Is(err, tokenstore.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	user, err := s.repo.GetByID(ctx, rt.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user for refresh token: %w", err)
+	}
+
+	accessToken, _, err := util.GenerateJWT(user.ID, user.Email, string(user.Role), s.roles.Permissions(user.Role), s.jwtSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	// Clear password before returning
 	user.Password = ""
-
 	return &domain.LoginResponse{
-		Token: token,
-		User:  user,
+		Token:        accessToken,
+		RefreshToken: rt.Token,
+		User:         user,
 	}, nil
 }
 
+// Logout revokes the refresh token so it can no longer be exchanged for a
+// new session, and denylists the access token's jti so it stops working
+// immediately instead of lingering until it expires on its own.
+func (s *userService) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	if claims, err := util.ValidateJWT(accessToken, s.jwtSecret); err == nil {
+		ttl := time.Until(claims.ExpiresAt.Time)
+		if ttl > 0 {
+			if err := s.tokens.DenylistJTI(ctx, claims.ID, ttl); err != nil {
+				return fmt.Errorf("failed to denylist access token: %w", err)
+			}
+		}
+	}
+
+	return s.tokens.Revoke(ctx, refreshToken)
+}
+
 func (s *userService) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
 	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -99,11 +284,75 @@ func (s *userService) GetUserByID(ctx context.Context, id string) (*domain.User,
 	return user, nil
 }
 
-func (s *userService) ValidateToken(ctx context.Context, token string) (string, error) {
+func (s *userService) ValidateToken(ctx context.Context, token string) (*domain.TokenClaims, error) {
 	claims, err := util.ValidateJWT(token, s.jwtSecret)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// A 2FA challenge token only ever authenticates the caller to
+	// LoginWithTOTP; it must never pass as a real access token here.
+	if claims.Purpose == util.PurposeTOTPChallenge {
+		return nil, ErrInvalidToken
+	}
+
+	denylisted, err := s.tokens.IsJTIDenylisted(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token denylist: %w", err)
+	}
+	if denylisted {
+		return nil, ErrTokenRevoked
+	}
+
+	return &domain.TokenClaims{
+		UserID:      claims.UserID,
+		Role:        claims.Role,
+		Permissions: claims.Permissions,
+	}, nil
+}
+
+// recordLoginFailure increments userID's consecutive-failure counter and
+// locks the account for loginLockDuration once it reaches loginMaxAttempts.
+func (s *userService) recordLoginFailure(ctx context.Context, userID string) error {
+	count, err := s.loginAttempts.RecordFailure(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count >= s.loginMaxAttempts {
+		if err := s.repo.LockUser(ctx, userID, time.Now().Add(s.loginLockDuration)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueSession generates a fresh access/refresh pair for user and persists
+// the refresh token as the start of a new rotation family.
+func (s *userService) issueSession(ctx context.Context, user *domain.User) (*domain.LoginResponse, error) {
+	accessToken, _, err := util.GenerateJWT(user.ID, user.Email, string(user.Role), s.roles.Permissions(user.Role), s.jwtSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	return claims.UserID, nil
+	refreshToken, err := util.GenerateRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	rt := &tokenstore.RefreshToken{
+		Token:     refreshToken,
+		UserID:    user.ID,
+		FamilyID:  uuid.New().String(),
+		ExpiresAt: time.Now().Add(RefreshTokenTTL),
+	}
+	if err := s.tokens.Save(ctx, rt); err != nil {
+		return nil, fmt.Errorf("failed to persist refresh token: %w", err)
+	}
+
+	user.Password = ""
+	return &domain.LoginResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		User:         user,
+	}, nil
 }
@@ -0,0 +1,102 @@
+package tokenstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore is the default Store backend: fine for local development and
+// single-instance deployments, but refresh tokens and the denylist don't
+// survive a restart or fan out across replicas.
+type memoryStore struct {
+	mu       sync.Mutex
+	tokens   map[string]*RefreshToken
+	denylist map[string]time.Time
+}
+
+// NewMemoryStore returns an in-process Store.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		tokens:   make(map[string]*RefreshToken),
+		denylist: make(map[string]time.Time),
+	}
+}
+
+func (s *memoryStore) Save(ctx context.Context, rt *RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[rt.Token] = rt
+	return nil
+}
+
+func (s *memoryStore) Rotate(ctx context.Context, oldToken, newToken string, ttl time.Duration) (*RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.tokens[oldToken]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if time.Now().After(existing.ExpiresAt) {
+		// Mirrors Redis expiring the key via EX: an expired token is
+		// indistinguishable from one that was never issued.
+		delete(s.tokens, oldToken)
+		return nil, ErrNotFound
+	}
+	if existing.Revoked {
+		// Still hand back the family so the caller can revoke it.
+		return &RefreshToken{FamilyID: existing.FamilyID}, ErrReused
+	}
+
+	existing.Revoked = true
+	next := &RefreshToken{
+		Token:     newToken,
+		UserID:    existing.UserID,
+		FamilyID:  existing.FamilyID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	s.tokens[newToken] = next
+	return next, nil
+}
+
+func (s *memoryStore) Revoke(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rt, ok := s.tokens[token]; ok {
+		rt.Revoked = true
+	}
+	return nil
+}
+
+func (s *memoryStore) RevokeFamily(ctx context.Context, familyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rt := range s.tokens {
+		if rt.FamilyID == familyID {
+			rt.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) DenylistJTI(ctx context.Context, jti string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.denylist[jti] = time.Now().Add(ttl)
+	return nil
+}
+
+func (s *memoryStore) IsJTIDenylisted(ctx context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiresAt, ok := s.denylist[jti]
+	if !ok {
+		return false, nil
+	}
+	if time.Now().After(expiresAt) {
+		delete(s.denylist, jti)
+		return false, nil
+	}
+	return true, nil
+}
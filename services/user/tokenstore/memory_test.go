@@ -0,0 +1,79 @@
+package tokenstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreRotate(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	original := &RefreshToken{Token: "original", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Save(ctx, original); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	rotated, err := store.Rotate(ctx, "original", "rotated", time.Hour)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if rotated.UserID != "user-1" || rotated.FamilyID != "family-1" {
+		t.Fatalf("Rotate() = %+v, want UserID/FamilyID carried over from original", rotated)
+	}
+
+	// Reusing the now-retired original token must be detected and must
+	// report the family so the caller can revoke it.
+	_, err = store.Rotate(ctx, "original", "stolen", time.Hour)
+	if !errors.Is(err, ErrReused) {
+		t.Fatalf("Rotate() on a reused token error = %v, want ErrReused", err)
+	}
+
+	_, err = store.Rotate(ctx, "unknown", "next", time.Hour)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Rotate() on an unknown token error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreRotateExpired(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	expired := &RefreshToken{Token: "expired", UserID: "user-1", FamilyID: "family-1", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := store.Save(ctx, expired); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if _, err := store.Rotate(ctx, "expired", "next", time.Hour); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Rotate() on an expired token error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreJTIDenylist(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.DenylistJTI(ctx, "jti-1", 10*time.Millisecond); err != nil {
+		t.Fatalf("DenylistJTI() error = %v", err)
+	}
+
+	denylisted, err := store.IsJTIDenylisted(ctx, "jti-1")
+	if err != nil {
+		t.Fatalf("IsJTIDenylisted() error = %v", err)
+	}
+	if !denylisted {
+		t.Fatalf("IsJTIDenylisted() = false, want true immediately after DenylistJTI")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	denylisted, err = store.IsJTIDenylisted(ctx, "jti-1")
+	if err != nil {
+		t.Fatalf("IsJTIDenylisted() error = %v", err)
+	}
+	if denylisted {
+		t.Fatalf("IsJTIDenylisted() = true, want false once the denylist entry expires")
+	}
+}
@@ -0,0 +1,170 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	refreshKeyPrefix  = "golearn:refresh:"
+	familyKeyPrefix   = "golearn:family:"
+	denylistKeyPrefix = "golearn:denylist:"
+)
+
+// rotateScript retires the old token and stores the new one atomically: if
+// the old token is missing it's a clean "not found", and if it was already
+// retired this is a reuse attempt — both of which the caller needs to
+// distinguish. It returns the old token's payload so the caller can carry
+// its UserID/FamilyID over to the replacement.
+var rotateScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if not old then
+	return 'not_found'
+end
+local decoded = cjson.decode(old)
+if decoded.Revoked then
+	return 'reused'
+end
+decoded.Revoked = true
+redis.call('SET', KEYS[1], cjson.encode(decoded), 'KEEPTTL')
+redis.call('SET', KEYS[2], ARGV[1], 'EX', ARGV[2])
+redis.call('SADD', KEYS[3], KEYS[2])
+return old
+`)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore returns a Store backed by Redis, suitable for production
+// and multi-replica deployments.
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Save(ctx context.Context, rt *RefreshToken) error {
+	payload, err := json.Marshal(rt)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(rt.ExpiresAt)
+	if err := s.client.Set(ctx, refreshKeyPrefix+rt.Token, payload, ttl).Err(); err != nil {
+		return err
+	}
+	return s.client.SAdd(ctx, familyKeyPrefix+rt.FamilyID, refreshKeyPrefix+rt.Token).Err()
+}
+
+func (s *redisStore) Rotate(ctx context.Context, oldToken, newToken string, ttl time.Duration) (*RefreshToken, error) {
+	var old RefreshToken
+	// The family key is itself data inside the old token, so read the old
+	// payload first to learn it; rotateScript then re-checks the old token
+	// and performs the swap atomically.
+	raw, err := s.client.Get(ctx, refreshKeyPrefix+oldToken).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(raw), &old); err != nil {
+		return nil, err
+	}
+
+	next := &RefreshToken{
+		Token:     newToken,
+		UserID:    old.UserID,
+		FamilyID:  old.FamilyID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	payload, err := json.Marshal(next)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := rotateScript.Run(ctx, s.client,
+		[]string{refreshKeyPrefix + oldToken, refreshKeyPrefix + newToken, familyKeyPrefix + old.FamilyID},
+		string(payload), int64(ttl.Seconds()),
+	).Text()
+	if err != nil {
+		return nil, err
+	}
+
+	switch res {
+	case "not_found":
+		return nil, ErrNotFound
+	case "reused":
+		// Still hand back the family so the caller can revoke it.
+		return &RefreshToken{FamilyID: old.FamilyID}, ErrReused
+	default:
+		return next, nil
+	}
+}
+
+func (s *redisStore) Revoke(ctx context.Context, token string) error {
+	raw, err := s.client.Get(ctx, refreshKeyPrefix+token).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var rt RefreshToken
+	if err := json.Unmarshal([]byte(raw), &rt); err != nil {
+		return err
+	}
+	rt.Revoked = true
+	updated, err := json.Marshal(rt)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, refreshKeyPrefix+token, updated, redis.KeepTTL).Err()
+}
+
+func (s *redisStore) RevokeFamily(ctx context.Context, familyID string) error {
+	members, err := s.client.SMembers(ctx, familyKeyPrefix+familyID).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range members {
+		raw, err := s.client.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		var rt RefreshToken
+		if err := json.Unmarshal([]byte(raw), &rt); err != nil {
+			return err
+		}
+		rt.Revoked = true
+		updated, err := json.Marshal(rt)
+		if err != nil {
+			return err
+		}
+		if err := s.client.Set(ctx, key, updated, redis.KeepTTL).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *redisStore) DenylistJTI(ctx context.Context, jti string, ttl time.Duration) error {
+	return s.client.Set(ctx, denylistKeyPrefix+jti, "1", ttl).Err()
+}
+
+func (s *redisStore) IsJTIDenylisted(ctx context.Context, jti string) (bool, error) {
+	exists, err := s.client.Exists(ctx, denylistKeyPrefix+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("tokenstore: failed to check denylist: %w", err)
+	}
+	return exists > 0, nil
+}
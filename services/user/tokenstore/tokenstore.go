@@ -0,0 +1,56 @@
+// Package tokenstore persists refresh tokens and the access-token denylist
+// behind a pluggable backend (in-process for local dev, Redis for anything
+// that needs to survive a restart or run with more than one replica).
+package tokenstore
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a refresh token is unknown or has
+	// already expired out of the store.
+	ErrNotFound = errors.New("refresh token not found")
+	// ErrReused is returned when a refresh token that was already rotated
+	// away is presented again, signalling the token was stolen.
+	ErrReused = errors.New("refresh token reuse detected")
+)
+
+// RefreshToken is a single refresh token in a rotation family. FamilyID is
+// shared by every token descended from the same login; rotating a token
+// marks it revoked and stores a successor in the same family, so reuse of
+// any ancestor can revoke the whole family.
+type RefreshToken struct {
+	Token     string
+	UserID    string
+	FamilyID  string
+	ExpiresAt time.Time
+	Revoked   bool
+}
+
+// Store persists refresh tokens and the access-token (JTI) denylist used to
+// make logout take effect before a short-lived access token would otherwise
+// expire on its own.
+type Store interface {
+	// Save stores a newly issued refresh token.
+	Save(ctx context.Context, rt *RefreshToken) error
+	// Rotate atomically retires oldToken and stores a new token in its
+	// place, carrying over oldToken's UserID and FamilyID. If oldToken was
+	// never seen, it returns ErrNotFound. If oldToken was already retired
+	// (reuse of a stale token), it returns ErrReused along with a
+	// RefreshToken whose FamilyID is populated so the caller can revoke
+	// the rest of the family.
+	Rotate(ctx context.Context, oldToken, newToken string, ttl time.Duration) (*RefreshToken, error)
+	// Revoke retires a single token, e.g. on an explicit logout.
+	Revoke(ctx context.Context, token string) error
+	// RevokeFamily marks every token in familyID as unusable, used once
+	// reuse of a retired token is detected.
+	RevokeFamily(ctx context.Context, familyID string) error
+	// DenylistJTI blocks a specific access token's jti until it would have
+	// expired anyway.
+	DenylistJTI(ctx context.Context, jti string, ttl time.Duration) error
+	// IsJTIDenylisted reports whether an access token's jti was revoked.
+	IsJTIDenylisted(ctx context.Context, jti string) (bool, error)
+}